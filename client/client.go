@@ -0,0 +1,279 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"math"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pipego/scheduler/common"
+	"github.com/pipego/scheduler/config"
+	"github.com/pipego/scheduler/server"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+const (
+	authScheme = "Bearer "
+)
+
+type Client interface {
+	Init(context.Context) error
+	Deinit(context.Context) error
+	Schedule(context.Context, common.Task, []common.Node) (Result, error)
+}
+
+// Config is the connection to the scheduler at address. TLS is enabled when
+// caFile is set, and mutual TLS when certFile and keyFile are set as well.
+// Keepalive pings are disabled if keepalive is zero, and the server has to
+// permit them.
+type Config struct {
+	Address    string
+	Name       string
+	Token      string
+	Tls        config.Tls
+	ServerName string
+	Keepalive  time.Duration
+	Timeout    time.Duration
+	Retries    int64
+	Backoff    time.Duration
+	TopN       int64
+	Options    []grpc.DialOption
+}
+
+// Result is the decision of the scheduler. Error, Code and Details are set if
+// the task is not scheduled.
+type Result struct {
+	Name       string
+	Error      string
+	Code       pb.ErrorCode
+	Details    string
+	Candidates []Candidate
+}
+
+type Candidate struct {
+	Name  string
+	Score int64
+}
+
+type client struct {
+	cfg  *Config
+	conn *grpc.ClientConn
+	cli  pb.ServerProtoClient
+}
+
+func New(_ context.Context, cfg *Config) Client {
+	return &client{
+		cfg: cfg,
+	}
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Timeout: 10 * time.Second,
+		Retries: 3,
+		Backoff: 100 * time.Millisecond,
+	}
+}
+
+func (c *client) Init(ctx context.Context) error {
+	creds, err := c.credentials()
+	if err != nil {
+		return errors.Wrap(err, "failed to load tls")
+	}
+
+	options := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32), grpc.MaxCallSendMsgSize(math.MaxInt32)),
+	}
+
+	if c.cfg.Keepalive > 0 {
+		options = append(options, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.cfg.Keepalive,
+			PermitWithoutStream: true,
+		}))
+	}
+
+	options = append(options, c.cfg.Options...)
+
+	conn, err := grpc.DialContext(ctx, c.cfg.Address, options...)
+	if err != nil {
+		return errors.Wrap(err, "failed to dial")
+	}
+
+	c.conn = conn
+	c.cli = pb.NewServerProtoClient(conn)
+
+	return nil
+}
+
+func (c *client) Deinit(_ context.Context) error {
+	if c.conn == nil {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// Schedule places task on one of nodes. Calls failing with Unavailable are
+// retried with exponential backoff. If the scheduler rejects the task, the
+// error is returned along with the result reported by the scheduler.
+func (c *client) Schedule(ctx context.Context, task common.Task, nodes []common.Node) (Result, error) {
+	req := NewRequest(task, nodes)
+	req.Metadata = &pb.Metadata{Name: c.cfg.Name}
+	req.TopN = c.cfg.TopN
+
+	if c.cfg.Token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, server.AuthKey, authScheme+c.cfg.Token)
+	}
+
+	reply, err := c.send(ctx, req)
+	if err != nil {
+		for _, item := range status.Convert(err).Details() {
+			if r, ok := item.(*pb.ServerReply); ok {
+				return NewResult(r), err
+			}
+		}
+		return Result{}, err
+	}
+
+	return NewResult(reply), nil
+}
+
+func (c *client) send(ctx context.Context, req *pb.ServerRequest) (*pb.ServerReply, error) {
+	helper := func() (*pb.ServerReply, error) {
+		ctx := ctx
+		if c.cfg.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.cfg.Timeout)
+			defer cancel()
+		}
+		return c.cli.SendServer(ctx, req)
+	}
+
+	backoff := c.cfg.Backoff
+
+	for i := int64(0); ; i++ {
+		reply, err := helper()
+		if status.Code(err) != codes.Unavailable || i >= c.cfg.Retries {
+			return reply, err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		backoff *= 2
+	}
+}
+
+func (c *client) credentials() (credentials.TransportCredentials, error) {
+	if c.cfg.Tls.CaFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	buf, err := os.ReadFile(c.cfg.Tls.CaFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read ca")
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(buf) {
+		return nil, errors.New("invalid ca")
+	}
+
+	t := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    pool,
+		ServerName: c.cfg.ServerName,
+	}
+
+	if c.cfg.Tls.CertFile != "" || c.cfg.Tls.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.cfg.Tls.CertFile, c.cfg.Tls.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load key pair")
+		}
+		t.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(t), nil
+}
+
+// NewRequest converts task and nodes into a request, as the reverse of the
+// conversion in the server.
+func NewRequest(task common.Task, nodes []common.Node) *pb.ServerRequest {
+	var buf []*pb.Node
+
+	for i := range nodes {
+		buf = append(buf, nodeHelper(&nodes[i]))
+	}
+
+	return &pb.ServerRequest{
+		ApiVersion: server.ApiVersion,
+		Kind:       server.Kind,
+		Spec: &pb.Spec{
+			Task:  taskHelper(&task),
+			Nodes: buf,
+		},
+	}
+}
+
+// NewResult converts reply into a result.
+func NewResult(reply *pb.ServerReply) Result {
+	var buf []Candidate
+
+	for _, item := range reply.GetCandidates() {
+		buf = append(buf, Candidate{Name: item.GetName(), Score: item.GetScore()})
+	}
+
+	return Result{
+		Name:       reply.GetName(),
+		Error:      reply.GetError(),
+		Code:       reply.GetCode(),
+		Details:    reply.GetDetails(),
+		Candidates: buf,
+	}
+}
+
+func taskHelper(t *common.Task) *pb.Task {
+	return &pb.Task{
+		Name:          t.Name,
+		NodeName:      t.NodeName,
+		NodeSelectors: t.NodeSelectors,
+		RequestedResource: &pb.RequestedResource{
+			MilliCPU: t.RequestedResource.MilliCPU,
+			Memory:   t.RequestedResource.Memory,
+			Storage:  t.RequestedResource.Storage,
+		},
+		ToleratesUnschedulable: t.ToleratesUnschedulable,
+	}
+}
+
+func nodeHelper(n *common.Node) *pb.Node {
+	return &pb.Node{
+		AllocatableResource: &pb.AllocatableResource{
+			MilliCPU: n.AllocatableResource.MilliCPU,
+			Memory:   n.AllocatableResource.Memory,
+			Storage:  n.AllocatableResource.Storage,
+		},
+		Host:  n.Host,
+		Label: n.Label,
+		Name:  n.Name,
+		RequestedResource: &pb.RequestedResource{
+			MilliCPU: n.RequestedResource.MilliCPU,
+			Memory:   n.RequestedResource.Memory,
+			Storage:  n.RequestedResource.Storage,
+		},
+		Unschedulable: n.Unschedulable,
+	}
+}
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/pipego/scheduler/common"
+	"github.com/pipego/scheduler/config"
+	"github.com/pipego/scheduler/scheduler"
+	"github.com/pipego/scheduler/server"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+const (
+	bufSize = 1024 * 1024
+)
+
+// testFake is both the logger and the scheduler of the server. It places each
+// task on the node named after it, after failing the first calls as unavailable.
+type testFake struct {
+	scheduler.Scheduler
+	fail  int
+	calls int
+}
+
+func (f *testFake) Init(context.Context) error   { return nil }
+func (f *testFake) Deinit(context.Context) error { return nil }
+func (f *testFake) Health(context.Context) error { return nil }
+func (f *testFake) Debug(string, ...zap.Field)   {}
+func (f *testFake) Info(string, ...zap.Field)    {}
+func (f *testFake) Warn(string, ...zap.Field)    {}
+func (f *testFake) Error(string, ...zap.Field)   {}
+
+func (f *testFake) Run(_ context.Context, task *common.Task, nodes []*common.Node) scheduler.Result {
+	f.calls++
+
+	if f.calls <= f.fail {
+		return scheduler.Result{Error: "scheduler is closed", Code: scheduler.CodeUnavailable}
+	}
+
+	for _, item := range nodes {
+		if item.Name == task.Name {
+			return scheduler.Result{Name: item.Name, Candidates: []scheduler.Candidate{{Name: item.Name, Score: 1}}}
+		}
+	}
+
+	return scheduler.Result{Error: "failed to filter", Code: scheduler.CodeUnschedulable}
+}
+
+// testClient serves the scheduler server with fake over bufconn, which only
+// accepts token1 for name1.
+func testClient(t *testing.T, fake *testFake, token string) Client {
+	ctx := context.Background()
+
+	lis := bufconn.Listen(bufSize)
+
+	c := config.Config{}
+	c.Spec.Server.Auth.Tokens = []config.Token{{Names: []string{"name1"}, Value: "token1"}}
+
+	srv := server.New(ctx, &server.Config{
+		Listeners: []net.Listener{lis},
+		Config:    c,
+		Logger:    fake,
+		Scheduler: fake,
+	})
+
+	if err := srv.Init(ctx); err != nil {
+		t.Fatalf("failed to init server")
+	}
+
+	go func() {
+		_ = srv.Run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		_ = srv.Deinit(ctx)
+	})
+
+	cfg := DefaultConfig()
+	cfg.Address = "bufnet"
+	cfg.Name = "name1"
+	cfg.Token = token
+	cfg.Backoff = time.Millisecond
+	cfg.TopN = 1
+	cfg.Options = []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	}
+
+	cli := New(ctx, cfg)
+	if err := cli.Init(ctx); err != nil {
+		t.Fatalf("failed to init")
+	}
+
+	t.Cleanup(func() {
+		_ = cli.Deinit(ctx)
+	})
+
+	return cli
+}
+
+func TestSchedule(t *testing.T) {
+	ctx := context.Background()
+	fake := &testFake{fail: 2}
+	c := testClient(t, fake, "token1")
+
+	task := common.Task{Name: "node2"}
+	nodes := []common.Node{{Name: "node1"}, {Name: "node2"}}
+
+	r, err := c.Schedule(ctx, task, nodes)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node2", r.Name)
+	assert.Equal(t, []Candidate{{Name: "node2", Score: 1}}, r.Candidates)
+	assert.Equal(t, 3, fake.calls)
+
+	r, err = c.Schedule(ctx, common.Task{Name: "node3"}, nodes)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_UNSCHEDULABLE, r.Code)
+	assert.Equal(t, 4, fake.calls)
+
+	fake.calls, fake.fail = 0, 10
+
+	_, err = c.Schedule(ctx, task, nodes)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 4, fake.calls)
+}
+
+func TestScheduleAuth(t *testing.T) {
+	fake := &testFake{}
+	c := testClient(t, fake, "token2")
+
+	_, err := c.Schedule(context.Background(), common.Task{Name: "node1"}, []common.Node{{Name: "node1"}})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, 0, fake.calls)
+}
+
+func TestNewRequest(t *testing.T) {
+	task := common.Task{
+		Name:              "task1",
+		NodeSelectors:     []string{"ssd"},
+		RequestedResource: common.Resource{MilliCPU: 256, Memory: 512, Storage: 1024},
+	}
+
+	nodes := []common.Node{
+		{
+			Name:                "node1",
+			Host:                "127.0.0.1",
+			AllocatableResource: common.Resource{MilliCPU: 1024},
+			Unschedulable:       true,
+		},
+	}
+
+	req := NewRequest(task, nodes)
+	assert.Equal(t, server.ApiVersion, req.GetApiVersion())
+	assert.Equal(t, server.Kind, req.GetKind())
+	assert.Equal(t, "task1", req.GetSpec().GetTask().GetName())
+	assert.Equal(t, []string{"ssd"}, req.GetSpec().GetTask().GetNodeSelectors())
+	assert.Equal(t, int64(512), req.GetSpec().GetTask().GetRequestedResource().GetMemory())
+	assert.Equal(t, 1, len(req.GetSpec().GetNodes()))
+	assert.Equal(t, "127.0.0.1", req.GetSpec().GetNodes()[0].GetHost())
+	assert.Equal(t, int64(1024), req.GetSpec().GetNodes()[0].GetAllocatableResource().GetMilliCPU())
+	assert.Equal(t, true, req.GetSpec().GetNodes()[0].GetUnschedulable())
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"os/signal"
@@ -21,9 +23,14 @@ import (
 	"github.com/pipego/scheduler/server"
 )
 
+const (
+	revisionLen = 8
+)
+
 var (
 	configFile string
-	listenUrl  string
+	httpUrl    string
+	listenUrls []string
 )
 
 var rootCmd = &cobra.Command{
@@ -32,7 +39,7 @@ var rootCmd = &cobra.Command{
 	Short:   "pipego scheduler",
 	Long:    `pipego scheduler`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if configFile == "" || listenUrl == "" {
+		if configFile == "" {
 			_ = cmd.Help()
 			return
 		}
@@ -59,8 +66,9 @@ func init() {
 	rootCmd.Flags().StringVarP(&configFile, "config-file", "c", "", "config file (.yml)")
 	_ = rootCmd.MarkFlagRequired("config-file")
 
-	rootCmd.Flags().StringVarP(&listenUrl, "listen-url", "l", "", "listen url (host:port)")
-	_ = rootCmd.MarkFlagRequired("listen-url")
+	rootCmd.Flags().StringSliceVarP(&listenUrls, "listen-url", "l", nil, "listen url (host:port, tcp://host:port or unix:///path), repeatable")
+
+	rootCmd.Flags().StringVarP(&httpUrl, "http-url", "t", "", "http listen url (host:port, tcp://host:port or unix:///path)")
 }
 
 func Execute() error {
@@ -68,21 +76,21 @@ func Execute() error {
 }
 
 func loadConfig() error {
-	helper := func(ctx context.Context, cfg *config.Config) (server.Server, error) {
+	helper := func(ctx context.Context, cfg *config.Config) (*server.Config, error) {
 		if err := viper.ReadInConfig(); err != nil {
 			return nil, errors.Wrap(err, "failed to read config")
 		}
 		if err := viper.Unmarshal(cfg); err != nil {
 			return nil, errors.Wrap(err, "failed to unmarshal config")
 		}
-		srv, err := initPipe(ctx, cfg)
+		c, err := initPipe(ctx, cfg)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to init pipe")
 		}
-		return srv, nil
+		c.Revision = revision()
+		return c, nil
 	}
 
-	cfg := config.New()
 	ctx := context.Background()
 	reload := make(chan bool, 1)
 
@@ -92,11 +100,13 @@ func loadConfig() error {
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 
-	srv, err := helper(ctx, cfg)
+	c, err := helper(ctx, config.New())
 	if err != nil {
 		return errors.Wrap(err, "failed to load")
 	}
 
+	srv := server.New(ctx, c)
+
 	if err = runPipe(ctx, srv); err != nil {
 		return errors.Wrap(err, "failed to run")
 	}
@@ -110,14 +120,14 @@ L:
 	for {
 		select {
 		case <-reload:
-			_ = stopPipe(ctx, srv)
-			srv, err = helper(ctx, cfg)
+			// Build the new pipe behind the running server, so that the listener is kept,
+			// and keep serving the current one if the new one fails
+			c, err = helper(ctx, config.New())
 			if err != nil {
-				return errors.Wrap(err, "failed to reload")
-			}
-			if err := runPipe(ctx, srv); err != nil {
-				return errors.Wrap(err, "failed to run")
+				srv.Rollback(ctx, revision(), err)
+				continue
 			}
+			_ = srv.Reload(ctx, c)
 		case <-sig:
 			_ = stopPipe(ctx, srv)
 			break L
@@ -127,7 +137,20 @@ L:
 	return nil
 }
 
-func initPipe(ctx context.Context, cfg *config.Config) (server.Server, error) {
+// revision returns the hash of the config file, which identifies the config
+// in reload status.
+func revision() string {
+	buf, err := os.ReadFile(viper.ConfigFileUsed())
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(buf)
+
+	return hex.EncodeToString(sum[:revisionLen])
+}
+
+func initPipe(ctx context.Context, cfg *config.Config) (*server.Config, error) {
 	log, err := initLogger(ctx, cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init logger")
@@ -148,12 +171,12 @@ func initPipe(ctx context.Context, cfg *config.Config) (server.Server, error) {
 		return nil, errors.Wrap(err, "failed to init scheduler")
 	}
 
-	srv, err := initServer(ctx, cfg, log, sched)
+	c, err := initServer(ctx, cfg, log, pl, sched)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init server")
 	}
 
-	return srv, nil
+	return c, nil
 }
 
 func initLogger(ctx context.Context, cfg *config.Config) (logger.Logger, error) {
@@ -202,18 +225,24 @@ func initScheduler(ctx context.Context, cfg *config.Config, pa parallelizer.Para
 	return scheduler.New(ctx, c), nil
 }
 
-func initServer(ctx context.Context, cfg *config.Config, log logger.Logger, sched scheduler.Scheduler) (server.Server, error) {
+func initServer(_ context.Context, cfg *config.Config, log logger.Logger, pl plugin.Plugin, sched scheduler.Scheduler) (*server.Config, error) {
 	c := server.DefaultConfig()
 	if c == nil {
 		return nil, errors.New("failed to config")
 	}
 
-	c.Address = listenUrl
+	// The flags take precedence over the config
+	c.Addresses = listenUrls
+	if len(c.Addresses) == 0 {
+		c.Addresses = cfg.Spec.Server.Listen
+	}
+	c.HttpAddress = httpUrl
 	c.Config = *cfg
 	c.Logger = log
+	c.Plugin = pl
 	c.Scheduler = sched
 
-	return server.New(ctx, c), nil
+	return c, nil
 }
 
 func runPipe(ctx context.Context, srv server.Server) error {
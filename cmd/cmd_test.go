@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 
@@ -58,6 +59,17 @@ func TestInitScheduler(t *testing.T) {
 func TestInitServer(t *testing.T) {
 	cfg := testInitConfig()
 
-	_, err := initServer(context.Background(), cfg, nil, nil)
+	_, err := initServer(context.Background(), cfg, nil, nil, nil)
 	assert.Equal(t, nil, err)
 }
+
+func TestRevision(t *testing.T) {
+	viper.SetConfigFile("../test/config/config.yml")
+
+	r := revision()
+	assert.Equal(t, revisionLen*2, len(r))
+	assert.Equal(t, r, revision())
+
+	viper.SetConfigFile("invalid.yml")
+	assert.Equal(t, "", revision())
+}
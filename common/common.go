@@ -42,9 +42,28 @@ const (
 	MaxTotalScore int64 = math.MaxInt64
 )
 
+// Args is the arguments of a per-node plugin call. State is what the PreFilter
+// or PreScore call of the plugin returned in this cycle, if any.
 type Args struct {
-	Node Node
-	Task Task
+	Node  Node
+	State []byte
+	Task  Task
+}
+
+// PreArgs is the arguments of a PreScore call, which is made once per cycle
+// with the feasible nodes.
+type PreArgs struct {
+	Nodes []Node
+	Task  Task
+}
+
+// NormalizeArgs is the arguments of a NormalizeScore call, which is made once
+// per cycle with the raw score of each node in Scores, in node order.
+type NormalizeArgs struct {
+	Nodes  []Node
+	Scores []int64
+	State  []byte
+	Task   Task
 }
 
 type Node struct {
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"context"
+	"sync"
+)
+
+// Cycle is the state of one scheduling cycle. It is created per request and
+// carried in the context through the scheduler and the plugin calls, so that
+// concurrent requests never share a task, a node set or plugin state.
+type Cycle struct {
+	ID    string
+	Task  *Task
+	Nodes []*Node
+
+	mutex sync.RWMutex
+	state map[string]interface{}
+}
+
+type cycleKey struct{}
+
+func NewCycle(id string, task *Task, nodes []*Node) *Cycle {
+	return &Cycle{
+		ID:    id,
+		Task:  task,
+		Nodes: nodes,
+		state: make(map[string]interface{}),
+	}
+}
+
+// Read returns the plugin state stored with key.
+func (c *Cycle) Read(key string) (interface{}, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	val, ok := c.state[key]
+
+	return val, ok
+}
+
+// Write stores the plugin state with key.
+func (c *Cycle) Write(key string, val interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.state[key] = val
+}
+
+func WithCycle(ctx context.Context, c *Cycle) context.Context {
+	return context.WithValue(ctx, cycleKey{}, c)
+}
+
+func CycleFrom(ctx context.Context) (*Cycle, bool) {
+	c, ok := ctx.Value(cycleKey{}).(*Cycle)
+	return c, ok
+}
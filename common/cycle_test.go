@@ -0,0 +1,33 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCycle(t *testing.T) {
+	ctx := context.Background()
+
+	_, ok := CycleFrom(ctx)
+	assert.Equal(t, false, ok)
+
+	task := Task{Name: "task1"}
+	c := NewCycle("id1", &task, []*Node{{Name: "node1"}})
+
+	_, ok = c.Read("key1")
+	assert.Equal(t, false, ok)
+
+	c.Write("key1", "val1")
+
+	val, ok := c.Read("key1")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "val1", val)
+
+	buf, ok := CycleFrom(WithCycle(ctx, c))
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "id1", buf.ID)
+	assert.Equal(t, "task1", buf.Task.Name)
+	assert.Equal(t, 1, len(buf.Nodes))
+}
@@ -1,5 +1,9 @@
 package config
 
+import (
+	"time"
+)
+
 type Config struct {
 	ApiVersion string   `yaml:"apiVersion"`
 	Kind       string   `yaml:"kind"`
@@ -16,11 +20,15 @@ type Spec struct {
 	Filter Plugin `yaml:"filter"`
 	Score  Plugin `yaml:"score"`
 	Logger Logger `yaml:"logger"`
+	Server Server `yaml:"server"`
 }
 
+// Plugin is the plugins of a phase. Mode applies to filter plugins only, see
+// ModeAll and ModeFirstNonEmpty.
 type Plugin struct {
 	Disabled []Disabled `yaml:"disabled"`
 	Enabled  []Enabled  `yaml:"enabled"`
+	Mode     string     `yaml:"mode"`
 }
 
 type Disabled struct {
@@ -28,11 +36,20 @@ type Disabled struct {
 	Path string `yaml:"path"`
 }
 
+// Enabled is a plugin in use. Its calls are abandoned after timeout, if set,
+// and a node whose call fails is handled by policy, see PolicyReject and
+// PolicySkip. Filter plugins reject by default, and fetch and score plugins
+// skip. The scores of a score plugin on the nodes are mapped into 0-100 by
+// normalize, if set, see NormalizeMinMax and NormalizeReverse, or else by the
+// NormalizeScore phase of the plugin, and the ones out of 0-100 are dropped.
 type Enabled struct {
-	Name     string `yaml:"name"`
-	Path     string `yaml:"path"`
-	Priority int64  `yaml:"priority"`
-	Weight   int64  `yaml:"weight"`
+	Name      string        `yaml:"name"`
+	Normalize string        `yaml:"normalize"`
+	Path      string        `yaml:"path"`
+	Policy    string        `yaml:"policy"`
+	Priority  int64         `yaml:"priority"`
+	Timeout   time.Duration `yaml:"timeout"`
+	Weight    int64         `yaml:"weight"`
 }
 
 type Logger struct {
@@ -45,6 +62,95 @@ type Logger struct {
 	MaxSize      int64  `yaml:"maxSize"`
 }
 
+// Server listens on the urls in listen, e.g. tcp://:28082 or
+// unix:///run/scheduler.sock, if --listen-url is not set.
+type Server struct {
+	Admission   Admission   `yaml:"admission"`
+	Auth        Auth        `yaml:"auth"`
+	Idempotency Idempotency `yaml:"idempotency"`
+	Jobs        Jobs        `yaml:"jobs"`
+	Listen      []string    `yaml:"listen"`
+	Shutdown    Shutdown    `yaml:"shutdown"`
+	Tls         Tls         `yaml:"tls"`
+}
+
+// Admission bounds the schedules running at once to maxConcurrent, with up to
+// queueDepth more waiting for them, and the rate of each metadata.name to
+// rateLimits. Zero means unlimited.
+type Admission struct {
+	MaxConcurrent int64       `yaml:"maxConcurrent"`
+	QueueDepth    int64       `yaml:"queueDepth"`
+	RateLimits    []RateLimit `yaml:"rateLimits"`
+}
+
+// RateLimit allows rate schedules per second, in bursts of up to burst, to
+// metadata.name. The name "*" applies to every name without a limit.
+type RateLimit struct {
+	Burst int64   `yaml:"burst"`
+	Name  string  `yaml:"name"`
+	Rate  float64 `yaml:"rate"`
+}
+
+// Auth enables authentication when any token is set.
+type Auth struct {
+	Tokens []Token `yaml:"tokens"`
+}
+
+// Token is a bearer token or a pre-shared key, allowed to use names in
+// metadata.name. Any name is allowed if names is empty.
+type Token struct {
+	Names []string `yaml:"names"`
+	Value string   `yaml:"value"`
+}
+
+// Idempotency reuses the decision of a task for the requests with the same
+// idempotency key within window, which is disabled if zero.
+type Idempotency struct {
+	Window time.Duration `yaml:"window"`
+}
+
+// Jobs bounds each submitted schedule to timeout, 1m by default, and retains
+// its result for ttl, 10m by default.
+type Jobs struct {
+	Timeout time.Duration `yaml:"timeout"`
+	Ttl     time.Duration `yaml:"ttl"`
+}
+
+// Shutdown drains the requests in flight for up to timeout, 30s by default.
+type Shutdown struct {
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Tls enables TLS when certFile and keyFile are set, and mutual TLS when
+// caFile is set as well. caFile alone is invalid.
+type Tls struct {
+	CaFile   string `yaml:"caFile"`
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+}
+
+const (
+	// ModeAll requires a node to pass every filter plugin.
+	ModeAll = "all"
+	// ModeFirstNonEmpty returns the nodes passing the first filter plugin which
+	// accepts any, by priority. This is the default.
+	ModeFirstNonEmpty = "firstNonEmpty"
+)
+
+const (
+	// NormalizeMinMax maps the lowest score to 0 and the highest one to 100.
+	NormalizeMinMax = "minMax"
+	// NormalizeReverse maps the lowest score to 100 and the highest one to 0.
+	NormalizeReverse = "reverse"
+)
+
+const (
+	// PolicyReject drops the node from the cycle.
+	PolicyReject = "reject"
+	// PolicySkip ignores the plugin for the node.
+	PolicySkip = "skip"
+)
+
 var (
 	Build   string
 	Version string
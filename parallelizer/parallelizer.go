@@ -14,6 +14,7 @@ const (
 
 type Parallelizer interface {
 	Init(context.Context, int) error
+	Until(context.Context, int, DoWorkPieceFunc)
 }
 
 type Config struct {
@@ -41,6 +42,8 @@ func (p *parallelizer) Init(ctx context.Context, parallelism int) error {
 	return nil
 }
 
+// Until runs doWorkPiece for every piece with the configured parallelism, in
+// chunks of chunkSizeFor pieces.
 func (p *parallelizer) Until(ctx context.Context, pieces int, doWorkPiece DoWorkPieceFunc) {
 	ParallelizeUntil(ctx, p.parallelism, pieces, doWorkPiece, WithChunkSize(p.chunkSizeFor(pieces, p.parallelism)))
 }
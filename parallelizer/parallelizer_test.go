@@ -1,6 +1,7 @@
 package parallelizer
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,3 +28,17 @@ func TestChunkSizeFor(t *testing.T) {
 	res = p.chunkSizeFor(num, parallelism)
 	assert.Equal(t, 1, res)
 }
+
+func TestUntil(t *testing.T) {
+	p := parallelizer{parallelism: DefaultParallelism}
+
+	buf := make([]int, 100)
+
+	p.Until(context.Background(), len(buf), func(index int) {
+		buf[index] = index
+	})
+
+	for i := range buf {
+		assert.Equal(t, i, buf[i])
+	}
+}
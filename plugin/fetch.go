@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"context"
 	"net/rpc"
 
 	gop "github.com/hashicorp/go-plugin"
@@ -18,6 +19,15 @@ func (n *FetchRPC) Run(host string) FetchResult {
 	return resp
 }
 
+// RunContext is Run, but abandons the call once ctx is done.
+func (n *FetchRPC) RunContext(ctx context.Context, host string) (FetchResult, error) {
+	var resp FetchResult
+	if err := call(ctx, n.client, "Plugin.Run", host, &resp); err != nil {
+		return FetchResult{}, err
+	}
+	return resp, nil
+}
+
 type FetchRPCServer struct {
 	Impl FetchImpl
 }
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"context"
 	"net/rpc"
 
 	gop "github.com/hashicorp/go-plugin"
@@ -20,6 +21,34 @@ func (n *FilterRPC) Run(args *common.Args) FilterResult {
 	return resp
 }
 
+// RunContext is Run, but abandons the call once ctx is done.
+func (n *FilterRPC) RunContext(ctx context.Context, args *common.Args) (FilterResult, error) {
+	var resp FilterResult
+	if err := call(ctx, n.client, "Plugin.Run", args, &resp); err != nil {
+		return FilterResult{}, err
+	}
+	return resp, nil
+}
+
+// PreFilterContext runs the PreFilter phase, which is a no-op if the plugin does not
+// implement it.
+func (n *FilterRPC) PreFilterContext(ctx context.Context, args *common.Task) (PreFilterResult, error) {
+	var resp PreFilterResult
+	if err := call(ctx, n.client, "Plugin.PreFilter", args, &resp); err != nil {
+		if unimplemented(err) {
+			return PreFilterResult{}, nil
+		}
+		return PreFilterResult{}, err
+	}
+	return resp, nil
+}
+
+// HooksContext returns the optional phases the plugin implements, which are none
+// if it is built before them.
+func (n *FilterRPC) HooksContext(ctx context.Context) ([]string, error) {
+	return hooksCall(ctx, n.client)
+}
+
 type FilterRPCServer struct {
 	Impl FilterImpl
 }
@@ -29,6 +58,18 @@ func (n *FilterRPCServer) Run(args *common.Args, resp *FilterResult) error {
 	return nil
 }
 
+func (n *FilterRPCServer) PreFilter(args *common.Task, resp *PreFilterResult) error {
+	if impl, ok := n.Impl.(PreFilterImpl); ok {
+		*resp = impl.PreFilter(args)
+	}
+	return nil
+}
+
+func (n *FilterRPCServer) Hooks(_ string, resp *[]string) error {
+	*resp, _ = hooksHelper(context.Background(), n.Impl)
+	return nil
+}
+
 type Filter struct {
 	Impl FilterImpl
 }
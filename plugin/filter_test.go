@@ -1,9 +1,174 @@
 package plugin
 
 import (
+	"context"
+	"net"
+	"net/rpc"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pipego/scheduler/common"
 )
 
+type testFilter struct {
+	delay time.Duration
+}
+
+func (f *testFilter) Run(args *common.Args) FilterResult {
+	time.Sleep(f.delay)
+
+	if args.Node.Name != args.Task.NodeName {
+		return FilterResult{Error: "node(s) didn't match the requested node name"}
+	}
+
+	return FilterResult{}
+}
+
 func TestFilter(t *testing.T) {
-	// BYPASS
+	helper := func(delay time.Duration) *FilterRPC {
+		srv := rpc.NewServer()
+		_ = srv.RegisterName("Plugin", &FilterRPCServer{Impl: &testFilter{delay: delay}})
+		c1, c2 := net.Pipe()
+		go srv.ServeConn(c1)
+		return &FilterRPC{client: rpc.NewClient(c2)}
+	}
+
+	args := &common.Args{
+		Node: common.Node{Name: "node1"},
+		Task: common.Task{NodeName: "node2"},
+	}
+
+	f := helper(0)
+	defer func() {
+		_ = f.client.Close()
+	}()
+
+	res, err := f.RunContext(context.Background(), args)
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, "", res.Error)
+
+	f = helper(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = f.RunContext(ctx, args)
+	assert.NotEqual(t, nil, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// testPreFilter passes the requested node name to Run in the state.
+type testPreFilter struct{}
+
+func (f *testPreFilter) PreFilter(task *common.Task) PreFilterResult {
+	if task.NodeName == "" {
+		return PreFilterResult{Error: "node name is not set"}
+	}
+
+	return PreFilterResult{State: []byte(task.NodeName)}
+}
+
+func (f *testPreFilter) Run(args *common.Args) FilterResult {
+	if args.Node.Name != string(args.State) {
+		return FilterResult{Error: "node(s) didn't match the requested node name"}
+	}
+
+	return FilterResult{}
+}
+
+// testLegacyServer is a filter plugin built before PreFilter.
+type testLegacyServer struct {
+	impl FilterImpl
+}
+
+func (s *testLegacyServer) Run(args *common.Args, resp *FilterResult) error {
+	*resp = s.impl.Run(args)
+	return nil
+}
+
+// testCountServer counts the PreFilter calls of a plugin.
+type testCountServer struct {
+	FilterRPCServer
+	calls int
+}
+
+func (s *testCountServer) PreFilter(args *common.Task, resp *PreFilterResult) error {
+	s.calls++
+	return s.FilterRPCServer.PreFilter(args, resp)
+}
+
+func TestPreFilter(t *testing.T) {
+	helper := func(rcvr interface{}) *FilterRPC {
+		srv := rpc.NewServer()
+		_ = srv.RegisterName("Plugin", rcvr)
+		c1, c2 := net.Pipe()
+		go srv.ServeConn(c1)
+		return &FilterRPC{client: rpc.NewClient(c2)}
+	}
+
+	count := &testCountServer{FilterRPCServer: FilterRPCServer{Impl: &testFilter{}}}
+
+	p := plugin{
+		cfg:   DefaultConfig(),
+		hooks: map[string]bool{},
+		filter: map[string]FilterImpl{
+			"PreFilter": helper(&FilterRPCServer{Impl: &testPreFilter{}}),
+			"Filter":    helper(count),
+			"Legacy":    helper(&testLegacyServer{impl: &testFilter{}}),
+		},
+	}
+
+	defer func() {
+		for _, item := range p.filter {
+			_ = item.(*FilterRPC).client.Close()
+		}
+	}()
+
+	task := &common.Task{NodeName: "node1"}
+	nodes := []*common.Node{{Name: "node1"}, {Name: "node2"}}
+	ctx := common.WithCycle(context.Background(), common.NewCycle("", task, nodes))
+
+	for name, impl := range p.filter {
+		assert.Equal(t, nil, p.initHooks(ctx, TypeFilter, name, impl))
+	}
+
+	assert.Equal(t, true, p.implements(TypeFilter, "PreFilter", HookPreFilter))
+	assert.Equal(t, false, p.implements(TypeFilter, "Filter", HookPreFilter))
+	assert.Equal(t, false, p.implements(TypeFilter, "Legacy", HookPreFilter))
+
+	_, err := p.RunPreFilter(ctx, "invalid", task)
+	assert.NotEqual(t, nil, err)
+
+	// The plugins without PreFilter are not called
+	res, err := p.RunPreFilter(ctx, "Filter", task)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, PreFilterResult{}, res)
+	assert.Equal(t, 0, count.calls)
+
+	res, err = p.RunPreFilter(ctx, "Legacy", task)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, PreFilterResult{}, res)
+
+	res, err = p.RunPreFilter(ctx, "PreFilter", &common.Task{})
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, "", res.Error)
+
+	res, err = p.RunPreFilter(ctx, "PreFilter", task)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", res.Error)
+
+	r, err := p.RunFilter(ctx, "PreFilter", task, nodes[0])
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", r.Error)
+
+	r, err = p.RunFilter(ctx, "PreFilter", task, nodes[1])
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, "", r.Error)
+
+	// The state is scoped to the cycle
+	r, _ = p.RunFilter(context.Background(), "PreFilter", task, nodes[0])
+	assert.NotEqual(t, "", r.Error)
 }
@@ -2,9 +2,11 @@ package plugin
 
 import (
 	"context"
+	"net/rpc"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/go-hclog"
 	gop "github.com/hashicorp/go-plugin"
@@ -17,9 +19,14 @@ import (
 type Plugin interface {
 	Init(context.Context) error
 	Deinit(context.Context) error
+	Health(context.Context) error
 	RunFetch(context.Context, string, string) (FetchResult, error)
+	RunPreFilter(context.Context, string, *common.Task) (PreFilterResult, error)
 	RunFilter(context.Context, string, *common.Task, *common.Node) (FilterResult, error)
+	RunPreScore(context.Context, string, *common.Task, []*common.Node) (PreScoreResult, error)
 	RunScore(context.Context, string, *common.Task, *common.Node) (ScoreResult, error)
+	RunNormalizeScore(context.Context, string, *common.Task, []*common.Node, []int64) (NormalizeScoreResult, error)
+	List(context.Context) []Info
 }
 
 type FetchImpl interface {
@@ -34,10 +41,76 @@ type ScoreImpl interface {
 	Run(*common.Args) ScoreResult
 }
 
+// PreFilterImpl is optionally implemented by filter plugins, to check the task
+// once per cycle before its nodes are filtered. The task is rejected if Error
+// is set, or else State is passed to every Filter call of the plugin in the
+// cycle in Args.
+type PreFilterImpl interface {
+	PreFilter(*common.Task) PreFilterResult
+}
+
+// PreScoreImpl is PreFilterImpl for score plugins, which is called with the
+// feasible nodes before they are scored.
+type PreScoreImpl interface {
+	PreScore(*common.PreArgs) PreScoreResult
+}
+
+// NormalizeScoreImpl is optionally implemented by score plugins, to map the raw
+// scores of the nodes into [MinNodeScore, MaxNodeScore] once they are all
+// scored. Scores are returned in node order, or left as is if nil.
+type NormalizeScoreImpl interface {
+	NormalizeScore(*common.NormalizeArgs) NormalizeScoreResult
+}
+
+// The RPC clients implement these to abandon calls once the context is done.
+type fetchContext interface {
+	RunContext(context.Context, string) (FetchResult, error)
+}
+
+type filterContext interface {
+	RunContext(context.Context, *common.Args) (FilterResult, error)
+}
+
+type preFilterContext interface {
+	PreFilterContext(context.Context, *common.Task) (PreFilterResult, error)
+}
+
+type scoreContext interface {
+	RunContext(context.Context, *common.Args) (ScoreResult, error)
+}
+
+type preScoreContext interface {
+	PreScoreContext(context.Context, *common.PreArgs) (PreScoreResult, error)
+}
+
+type normalizeScoreContext interface {
+	NormalizeScoreContext(context.Context, *common.NormalizeArgs) (NormalizeScoreResult, error)
+}
+
+// hooksContext is implemented by the RPC clients, to ask the plugin subprocess
+// which of the optional phases it implements.
+type hooksContext interface {
+	HooksContext(context.Context) ([]string, error)
+}
+
 type Config struct {
 	Config config.Config
 }
 
+const (
+	TypeFetch  = "fetch"
+	TypeFilter = "filter"
+	TypeScore  = "score"
+)
+
+// The optional phases of the plugins, see PreFilterImpl, PreScoreImpl and
+// NormalizeScoreImpl.
+const (
+	HookPreFilter      = "PreFilter"
+	HookPreScore       = "PreScore"
+	HookNormalizeScore = "NormalizeScore"
+)
+
 type FetchResult struct {
 	AllocatableResource common.Resource
 	RequestedResource   common.Resource
@@ -47,16 +120,49 @@ type FilterResult struct {
 	Error string
 }
 
+type PreFilterResult struct {
+	Error string
+	State []byte
+}
+
 type ScoreResult struct {
 	Score int64
 }
 
+type PreScoreResult struct {
+	Error string
+	State []byte
+}
+
+type NormalizeScoreResult struct {
+	Error  string
+	Scores []int64
+}
+
+// Info describes a loaded plugin. Pid is zero once its subprocess exits.
+type Info struct {
+	Name     string
+	Type     string
+	Path     string
+	Enabled  bool
+	Priority int64
+	Weight   int64
+	Pid      int64
+}
+
+type instance struct {
+	info   Info
+	client *gop.Client
+}
+
 type plugin struct {
-	cfg    *Config
-	client []*gop.Client
-	fetch  map[string]FetchImpl
-	filter map[string]FilterImpl
-	score  map[string]ScoreImpl
+	cfg       *Config
+	client    []*gop.Client
+	instances []instance
+	fetch     map[string]FetchImpl
+	filter    map[string]FilterImpl
+	score     map[string]ScoreImpl
+	hooks     map[string]bool
 }
 
 var (
@@ -83,12 +189,26 @@ func DefaultConfig() *Config {
 	return &Config{}
 }
 
+// Init starts the plugins of every phase. If any fails, the ones started so far
+// are killed as well, so that a failed reload leaves no subprocess behind.
 func (p *plugin) Init(ctx context.Context) error {
 	var err error
 
+	p.hooks = make(map[string]bool)
+
+	// A phase without plugins returns no error, and keeps the others
+	helper := func(cli []*gop.Client, err error) {
+		if err == nil {
+			_ = p.deinitHelper(ctx, cli)
+			return
+		}
+		_ = p.deinitHelper(ctx, append(p.client, cli...))
+		p.client = nil
+	}
+
 	cli, pl, err := p.initPlugin(ctx, &p.cfg.Config.Spec.Fetch, &Fetch{})
 	if err != nil {
-		_ = p.deinitHelper(ctx, cli)
+		helper(cli, err)
 		return errors.Wrap(err, "failed to init fetch")
 	}
 
@@ -101,7 +221,7 @@ func (p *plugin) Init(ctx context.Context) error {
 
 	cli, pl, err = p.initPlugin(ctx, &p.cfg.Config.Spec.Filter, &Filter{})
 	if err != nil || len(pl) == 0 {
-		_ = p.deinitHelper(ctx, cli)
+		helper(cli, err)
 		return errors.Wrap(err, "failed to init filter")
 	}
 
@@ -114,7 +234,7 @@ func (p *plugin) Init(ctx context.Context) error {
 
 	cli, pl, err = p.initPlugin(ctx, &p.cfg.Config.Spec.Score, &Score{})
 	if err != nil || len(pl) == 0 {
-		_ = p.deinitHelper(ctx, cli)
+		helper(cli, err)
 		return errors.Wrap(err, "failed to init score")
 	}
 
@@ -132,29 +252,36 @@ func (p *plugin) initPlugin(ctx context.Context, cfg *config.Plugin, impl gop.Pl
 	var cli []*gop.Client
 	pl := make(map[string]interface{})
 
-	helper := func(name, _path string) error {
-		if _, ok := pl[name]; ok {
+	helper := func(info Info) error {
+		if _, ok := pl[info.Name]; ok {
 			return errors.New("duplicate name")
 		}
-		c, i, err := p.initInstance(ctx, name, _path, impl)
+		c, i, err := p.initInstance(ctx, info.Name, info.Path, impl)
 		if err != nil {
 			return errors.New("failed to init instance")
 		}
 		cli = append(cli, c)
-		pl[name] = i
+		if err := p.initHooks(ctx, info.Type, info.Name, i); err != nil {
+			return errors.New("failed to init hooks")
+		}
+		pl[info.Name] = i
+		p.instances = append(p.instances, instance{info: info, client: c})
 		return nil
 	}
 
+	typ := typeHelper(impl)
+
 	for _, item := range cfg.Disabled {
 		p, _ := filepath.Abs(item.Path)
-		if err := helper(item.Name, p); err != nil {
+		if err := helper(Info{Name: item.Name, Type: typ, Path: p}); err != nil {
 			return cli, pl, err
 		}
 	}
 
 	for _, item := range cfg.Enabled {
 		p, _ := filepath.Abs(item.Path)
-		if err := helper(item.Name, p); err != nil {
+		info := Info{Name: item.Name, Type: typ, Path: p, Enabled: true, Priority: item.Priority, Weight: item.Weight}
+		if err := helper(info); err != nil {
 			return cli, pl, err
 		}
 	}
@@ -189,6 +316,26 @@ func (p *plugin) initInstance(ctx context.Context, name, _path string, impl gop.
 	return client, raw, nil
 }
 
+// initHooks asks impl once which optional phases it implements, so that the
+// others are not called in every cycle.
+func (p *plugin) initHooks(ctx context.Context, typ, name string, impl interface{}) error {
+	hooks, err := hooksHelper(ctx, impl)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range hooks {
+		p.hooks[typ+"/"+name+"/"+item] = true
+	}
+
+	return nil
+}
+
+// implements returns true if the plugin name of typ implements hook.
+func (p *plugin) implements(typ, name, hook string) bool {
+	return p.hooks[typ+"/"+name+"/"+hook]
+}
+
 func (p *plugin) Deinit(ctx context.Context) error {
 	return p.deinitHelper(ctx, p.client)
 }
@@ -201,36 +348,334 @@ func (p *plugin) deinitHelper(_ context.Context, cli []*gop.Client) error {
 	return nil
 }
 
-func (p *plugin) RunFetch(_ context.Context, name, host string) (FetchResult, error) {
+// Health returns an error if any enabled plugin is not dispensed, or any plugin
+// subprocess is not alive.
+func (p *plugin) Health(_ context.Context) error {
+	for _, item := range p.cfg.Config.Spec.Fetch.Enabled {
+		if _, ok := p.fetch[item.Name]; !ok {
+			return errors.New("invalid fetch " + item.Name)
+		}
+	}
+
+	for _, item := range p.cfg.Config.Spec.Filter.Enabled {
+		if _, ok := p.filter[item.Name]; !ok {
+			return errors.New("invalid filter " + item.Name)
+		}
+	}
+
+	for _, item := range p.cfg.Config.Spec.Score.Enabled {
+		if _, ok := p.score[item.Name]; !ok {
+			return errors.New("invalid score " + item.Name)
+		}
+	}
+
+	for _, item := range p.client {
+		if item.Exited() {
+			return errors.New("plugin exited")
+		}
+		c, err := item.Client()
+		if err != nil {
+			return errors.Wrap(err, "failed to get client")
+		}
+		if err := c.Ping(); err != nil {
+			return errors.Wrap(err, "failed to ping")
+		}
+	}
+
+	return nil
+}
+
+// List returns the loaded plugins, disabled ones included, in load order.
+func (p *plugin) List(_ context.Context) []Info {
+	buf := make([]Info, 0, len(p.instances))
+
+	for _, item := range p.instances {
+		info := item.info
+		if !item.client.Exited() {
+			if r := item.client.ReattachConfig(); r != nil {
+				info.Pid = int64(r.Pid)
+			}
+		}
+		buf = append(buf, info)
+	}
+
+	return buf
+}
+
+func (p *plugin) RunFetch(ctx context.Context, name, host string) (FetchResult, error) {
 	if _, ok := p.fetch[name]; !ok {
 		return FetchResult{}, errors.New("invalid name")
 	}
 
+	ctx, cancel := withTimeout(ctx, p.cfg.Config.Spec.Fetch.Enabled, name)
+	defer cancel()
+
+	if c, ok := p.fetch[name].(fetchContext); ok {
+		return c.RunContext(ctx, host)
+	}
+
 	return p.fetch[name].Run(host), nil
 }
 
-func (p *plugin) RunFilter(_ context.Context, name string, task *common.Task, node *common.Node) (FilterResult, error) {
+// RunPreFilter runs the PreFilter phase of the filter plugin name, and stores
+// the state returned in the cycle of ctx for its Filter calls.
+func (p *plugin) RunPreFilter(ctx context.Context, name string, task *common.Task) (PreFilterResult, error) {
+	if _, ok := p.filter[name]; !ok {
+		return PreFilterResult{}, errors.New("invalid name")
+	}
+
+	if !p.implements(TypeFilter, name, HookPreFilter) {
+		return PreFilterResult{}, nil
+	}
+
+	ctx, cancel := withTimeout(ctx, p.cfg.Config.Spec.Filter.Enabled, name)
+	defer cancel()
+
+	var res PreFilterResult
+	var err error
+
+	switch impl := p.filter[name].(type) {
+	case preFilterContext:
+		res, err = impl.PreFilterContext(ctx, task)
+	case PreFilterImpl:
+		res = impl.PreFilter(task)
+	}
+
+	if err == nil {
+		writeState(ctx, TypeFilter, name, res.State)
+	}
+
+	return res, err
+}
+
+func (p *plugin) RunFilter(ctx context.Context, name string, task *common.Task, node *common.Node) (FilterResult, error) {
 	if _, ok := p.filter[name]; !ok {
 		return FilterResult{}, errors.New("invalid name")
 	}
 
 	args := &common.Args{
-		Node: *node,
-		Task: *task,
+		Node:  *node,
+		State: readState(ctx, TypeFilter, name),
+		Task:  *task,
+	}
+
+	ctx, cancel := withTimeout(ctx, p.cfg.Config.Spec.Filter.Enabled, name)
+	defer cancel()
+
+	if c, ok := p.filter[name].(filterContext); ok {
+		return c.RunContext(ctx, args)
 	}
 
 	return p.filter[name].Run(args), nil
 }
 
-func (p *plugin) RunScore(_ context.Context, name string, task *common.Task, node *common.Node) (ScoreResult, error) {
+// RunPreScore runs the PreScore phase of the score plugin name, and stores the
+// state returned in the cycle of ctx for its Score calls.
+func (p *plugin) RunPreScore(ctx context.Context, name string, task *common.Task, nodes []*common.Node) (PreScoreResult, error) {
+	if _, ok := p.score[name]; !ok {
+		return PreScoreResult{}, errors.New("invalid name")
+	}
+
+	if !p.implements(TypeScore, name, HookPreScore) {
+		return PreScoreResult{}, nil
+	}
+
+	args := preArgsHelper(task, nodes)
+
+	ctx, cancel := withTimeout(ctx, p.cfg.Config.Spec.Score.Enabled, name)
+	defer cancel()
+
+	var res PreScoreResult
+	var err error
+
+	switch impl := p.score[name].(type) {
+	case preScoreContext:
+		res, err = impl.PreScoreContext(ctx, args)
+	case PreScoreImpl:
+		res = impl.PreScore(args)
+	}
+
+	if err == nil {
+		writeState(ctx, TypeScore, name, res.State)
+	}
+
+	return res, err
+}
+
+func (p *plugin) RunScore(ctx context.Context, name string, task *common.Task, node *common.Node) (ScoreResult, error) {
 	if _, ok := p.score[name]; !ok {
 		return ScoreResult{}, errors.New("invalid name")
 	}
 
 	args := &common.Args{
-		Node: *node,
-		Task: *task,
+		Node:  *node,
+		State: readState(ctx, TypeScore, name),
+		Task:  *task,
+	}
+
+	ctx, cancel := withTimeout(ctx, p.cfg.Config.Spec.Score.Enabled, name)
+	defer cancel()
+
+	if c, ok := p.score[name].(scoreContext); ok {
+		return c.RunContext(ctx, args)
 	}
 
 	return p.score[name].Run(args), nil
 }
+
+// RunNormalizeScore runs the NormalizeScore phase of the score plugin name with
+// the raw scores of nodes, which are left as is if the plugin does not
+// implement it.
+func (p *plugin) RunNormalizeScore(ctx context.Context, name string, task *common.Task, nodes []*common.Node,
+	scores []int64) (NormalizeScoreResult, error) {
+	if _, ok := p.score[name]; !ok {
+		return NormalizeScoreResult{}, errors.New("invalid name")
+	}
+
+	if !p.implements(TypeScore, name, HookNormalizeScore) {
+		return NormalizeScoreResult{}, nil
+	}
+
+	pre := preArgsHelper(task, nodes)
+
+	args := &common.NormalizeArgs{
+		Nodes:  pre.Nodes,
+		Scores: scores,
+		State:  readState(ctx, TypeScore, name),
+		Task:   pre.Task,
+	}
+
+	ctx, cancel := withTimeout(ctx, p.cfg.Config.Spec.Score.Enabled, name)
+	defer cancel()
+
+	var res NormalizeScoreResult
+	var err error
+
+	switch impl := p.score[name].(type) {
+	case normalizeScoreContext:
+		res, err = impl.NormalizeScoreContext(ctx, args)
+	case NormalizeScoreImpl:
+		res = impl.NormalizeScore(args)
+	}
+
+	if err == nil && res.Scores != nil && len(res.Scores) != len(scores) {
+		return NormalizeScoreResult{}, errors.New("invalid scores")
+	}
+
+	return res, err
+}
+
+func typeHelper(impl gop.Plugin) string {
+	switch impl.(type) {
+	case *Fetch:
+		return TypeFetch
+	case *Filter:
+		return TypeFilter
+	case *Score:
+		return TypeScore
+	default:
+		return ""
+	}
+}
+
+func preArgsHelper(task *common.Task, nodes []*common.Node) *common.PreArgs {
+	args := &common.PreArgs{
+		Nodes: make([]common.Node, len(nodes)),
+		Task:  *task,
+	}
+
+	for i := range nodes {
+		args.Nodes[i] = *nodes[i]
+	}
+
+	return args
+}
+
+// hooksHelper returns the optional phases impl implements, which are asked of
+// the plugin subprocess for the RPC clients. Plugins built before the phases
+// implement none.
+func hooksHelper(ctx context.Context, impl interface{}) ([]string, error) {
+	if c, ok := impl.(hooksContext); ok {
+		return c.HooksContext(ctx)
+	}
+
+	var buf []string
+
+	if _, ok := impl.(PreFilterImpl); ok {
+		buf = append(buf, HookPreFilter)
+	}
+
+	if _, ok := impl.(PreScoreImpl); ok {
+		buf = append(buf, HookPreScore)
+	}
+
+	if _, ok := impl.(NormalizeScoreImpl); ok {
+		buf = append(buf, HookNormalizeScore)
+	}
+
+	return buf, nil
+}
+
+// readState returns the state of the plugin name of typ in the cycle of ctx.
+func readState(ctx context.Context, typ, name string) []byte {
+	c, ok := common.CycleFrom(ctx)
+	if !ok {
+		return nil
+	}
+
+	val, ok := c.Read(typ + "/" + name)
+	if !ok {
+		return nil
+	}
+
+	return val.([]byte)
+}
+
+func writeState(ctx context.Context, typ, name string, state []byte) {
+	if c, ok := common.CycleFrom(ctx); ok && state != nil {
+		c.Write(typ+"/"+name, state)
+	}
+}
+
+// unimplemented returns true if the plugin does not implement the method
+// called, e.g. PreFilter.
+func unimplemented(err error) bool {
+	var e rpc.ServerError
+	return errors.As(err, &e) && strings.HasPrefix(string(e), "rpc: can't find method")
+}
+
+// withTimeout bounds ctx by the timeout of the enabled plugin name, if any.
+func withTimeout(ctx context.Context, enabled []config.Enabled, name string) (context.Context, context.CancelFunc) {
+	for _, item := range enabled {
+		if item.Name == name && item.Timeout > 0 {
+			return context.WithTimeout(ctx, item.Timeout)
+		}
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// hooksCall asks the plugin for the optional phases it implements.
+func hooksCall(ctx context.Context, client *rpc.Client) ([]string, error) {
+	var resp []string
+	if err := call(ctx, client, "Plugin.Hooks", "", &resp); err != nil {
+		if unimplemented(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// call runs method on the plugin, and returns once ctx is done without waiting
+// for the reply, which is dropped by net/rpc when it arrives.
+func call(ctx context.Context, client *rpc.Client, method string, args, reply interface{}) error {
+	c := client.Go(method, args, reply, make(chan *rpc.Call, 1))
+
+	select {
+	case <-c.Done:
+		return c.Error
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "failed to call")
+	}
+}
@@ -3,6 +3,7 @@ package plugin
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -14,7 +15,7 @@ func TestInitPlugin(t *testing.T) {
 	ctx := context.Background()
 
 	cfg := config.Plugin{}
-	pl := plugin{}
+	pl := plugin{cfg: DefaultConfig()}
 
 	c, p, err := pl.initPlugin(ctx, &cfg, &Fetch{})
 	assert.Equal(t, nil, err)
@@ -93,7 +94,7 @@ func TestInitPlugin(t *testing.T) {
 
 func TestInitInstance(t *testing.T) {
 	ctx := context.Background()
-	pl := plugin{}
+	pl := plugin{cfg: DefaultConfig()}
 
 	name := ""
 	_path := ""
@@ -124,7 +125,7 @@ func TestInitInstance(t *testing.T) {
 func TestRunFetch(t *testing.T) {
 	ctx := context.Background()
 	cfg := config.Plugin{}
-	pl := plugin{}
+	pl := plugin{cfg: DefaultConfig()}
 
 	cfg.Disabled = []config.Disabled{}
 
@@ -154,7 +155,7 @@ func TestRunFetch(t *testing.T) {
 func TestRunFilter(t *testing.T) {
 	ctx := context.Background()
 	cfg := config.Plugin{}
-	pl := plugin{}
+	pl := plugin{cfg: DefaultConfig()}
 
 	cfg.Disabled = []config.Disabled{}
 
@@ -187,7 +188,7 @@ func TestRunFilter(t *testing.T) {
 func TestRunScore(t *testing.T) {
 	ctx := context.Background()
 	cfg := config.Plugin{}
-	pl := plugin{}
+	pl := plugin{cfg: DefaultConfig()}
 
 	cfg.Disabled = []config.Disabled{}
 
@@ -216,3 +217,157 @@ func TestRunScore(t *testing.T) {
 
 	_ = pl.deinitHelper(ctx, c)
 }
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := config.Config{
+		Spec: config.Spec{
+			Filter: config.Plugin{
+				Enabled: []config.Enabled{
+					{
+						Name: "NodeName",
+						Path: "../filter-nodename",
+					},
+				},
+			},
+			Score: config.Plugin{
+				Enabled: []config.Enabled{
+					{
+						Name: "NodeResourcesFit",
+						Path: "../score-noderesourcesfit",
+					},
+				},
+			},
+		},
+	}
+
+	pl := plugin{cfg: &Config{Config: cfg}}
+	err := pl.Health(ctx)
+	assert.NotEqual(t, nil, err)
+
+	err = pl.Init(ctx)
+	assert.Equal(t, nil, err)
+
+	err = pl.Health(ctx)
+	assert.Equal(t, nil, err)
+
+	_ = pl.Deinit(ctx)
+
+	err = pl.Health(ctx)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestWithTimeout(t *testing.T) {
+	enabled := []config.Enabled{
+		{Name: "name1"},
+		{Name: "name2", Timeout: time.Second},
+	}
+
+	ctx, cancel := withTimeout(context.Background(), enabled, "name1")
+	_, ok := ctx.Deadline()
+	assert.Equal(t, false, ok)
+	cancel()
+
+	ctx, cancel = withTimeout(context.Background(), enabled, "name2")
+	_, ok = ctx.Deadline()
+	assert.Equal(t, true, ok)
+	cancel()
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := config.Config{
+		Spec: config.Spec{
+			Filter: config.Plugin{
+				Disabled: []config.Disabled{
+					{
+						Name: "NodeAffinity",
+						Path: "../filter-nodeaffinity",
+					},
+				},
+				Enabled: []config.Enabled{
+					{
+						Name:     "NodeName",
+						Path:     "../filter-nodename",
+						Priority: 1,
+					},
+				},
+			},
+			Score: config.Plugin{
+				Enabled: []config.Enabled{
+					{
+						Name:   "NodeResourcesFit",
+						Path:   "../score-noderesourcesfit",
+						Weight: 2,
+					},
+				},
+			},
+		},
+	}
+
+	pl := plugin{cfg: &Config{Config: cfg}}
+	assert.Equal(t, 0, len(pl.List(ctx)))
+
+	err := pl.Init(ctx)
+	assert.Equal(t, nil, err)
+
+	buf := pl.List(ctx)
+	assert.Equal(t, 3, len(buf))
+	assert.Equal(t, Info{Name: "NodeAffinity", Type: TypeFilter, Path: buf[0].Path, Pid: buf[0].Pid}, buf[0])
+	assert.Equal(t, true, buf[1].Enabled)
+	assert.Equal(t, int64(1), buf[1].Priority)
+	assert.Equal(t, TypeScore, buf[2].Type)
+	assert.Equal(t, int64(2), buf[2].Weight)
+	assert.NotEqual(t, int64(0), buf[2].Pid)
+
+	_ = pl.Deinit(ctx)
+	assert.Equal(t, int64(0), pl.List(ctx)[2].Pid)
+}
+
+func TestInitFailure(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := config.Config{
+		Spec: config.Spec{
+			Fetch: config.Plugin{
+				Enabled: []config.Enabled{
+					{
+						Name: "LocalHost",
+						Path: "../fetch-localhost",
+					},
+				},
+			},
+			Filter: config.Plugin{
+				Enabled: []config.Enabled{
+					{
+						Name: "NodeName",
+						Path: "../filter-nodename",
+					},
+				},
+			},
+			Score: config.Plugin{
+				Enabled: []config.Enabled{
+					{
+						Name: "NodeResourcesFit",
+						Path: "../score-invalid",
+					},
+				},
+			},
+		},
+	}
+
+	pl := plugin{cfg: &Config{Config: cfg}}
+
+	err := pl.Init(ctx)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 0, len(pl.client))
+
+	buf := pl.List(ctx)
+	assert.Equal(t, 2, len(buf))
+
+	for _, item := range buf {
+		assert.Equal(t, int64(0), item.Pid)
+	}
+}
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"context"
 	"net/rpc"
 
 	gop "github.com/hashicorp/go-plugin"
@@ -20,6 +21,47 @@ func (n *ScoreRPC) Run(args *common.Args) ScoreResult {
 	return resp
 }
 
+// RunContext is Run, but abandons the call once ctx is done.
+func (n *ScoreRPC) RunContext(ctx context.Context, args *common.Args) (ScoreResult, error) {
+	var resp ScoreResult
+	if err := call(ctx, n.client, "Plugin.Run", args, &resp); err != nil {
+		return ScoreResult{}, err
+	}
+	return resp, nil
+}
+
+// PreScoreContext runs the PreScore phase, which is a no-op if the plugin does not
+// implement it.
+func (n *ScoreRPC) PreScoreContext(ctx context.Context, args *common.PreArgs) (PreScoreResult, error) {
+	var resp PreScoreResult
+	if err := call(ctx, n.client, "Plugin.PreScore", args, &resp); err != nil {
+		if unimplemented(err) {
+			return PreScoreResult{}, nil
+		}
+		return PreScoreResult{}, err
+	}
+	return resp, nil
+}
+
+// NormalizeScoreContext runs the NormalizeScore phase, which leaves the scores as
+// is if the plugin does not implement it.
+func (n *ScoreRPC) NormalizeScoreContext(ctx context.Context, args *common.NormalizeArgs) (NormalizeScoreResult, error) {
+	var resp NormalizeScoreResult
+	if err := call(ctx, n.client, "Plugin.NormalizeScore", args, &resp); err != nil {
+		if unimplemented(err) {
+			return NormalizeScoreResult{}, nil
+		}
+		return NormalizeScoreResult{}, err
+	}
+	return resp, nil
+}
+
+// HooksContext returns the optional phases the plugin implements, which are none
+// if it is built before them.
+func (n *ScoreRPC) HooksContext(ctx context.Context) ([]string, error) {
+	return hooksCall(ctx, n.client)
+}
+
 type ScoreRPCServer struct {
 	Impl ScoreImpl
 }
@@ -29,6 +71,25 @@ func (n *ScoreRPCServer) Run(args *common.Args, resp *ScoreResult) error {
 	return nil
 }
 
+func (n *ScoreRPCServer) PreScore(args *common.PreArgs, resp *PreScoreResult) error {
+	if impl, ok := n.Impl.(PreScoreImpl); ok {
+		*resp = impl.PreScore(args)
+	}
+	return nil
+}
+
+func (n *ScoreRPCServer) NormalizeScore(args *common.NormalizeArgs, resp *NormalizeScoreResult) error {
+	if impl, ok := n.Impl.(NormalizeScoreImpl); ok {
+		*resp = impl.NormalizeScore(args)
+	}
+	return nil
+}
+
+func (n *ScoreRPCServer) Hooks(_ string, resp *[]string) error {
+	*resp, _ = hooksHelper(context.Background(), n.Impl)
+	return nil
+}
+
 type Score struct {
 	Impl ScoreImpl
 }
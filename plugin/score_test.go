@@ -1,9 +1,124 @@
 package plugin
 
 import (
+	"context"
+	"net"
+	"net/rpc"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pipego/scheduler/common"
 )
 
 func TestScore(t *testing.T) {
 	// BYPASS
 }
+
+type testScore struct{}
+
+func (s *testScore) Run(args *common.Args) ScoreResult {
+	return ScoreResult{Score: args.Node.AllocatableResource.MilliCPU}
+}
+
+// testNormalizeScore scores the nodes by their share of the total.
+type testNormalizeScore struct {
+	testScore
+}
+
+func (s *testNormalizeScore) NormalizeScore(args *common.NormalizeArgs) NormalizeScoreResult {
+	var total int64
+
+	for _, item := range args.Scores {
+		total += item
+	}
+
+	if total == 0 {
+		return NormalizeScoreResult{Error: "total is zero"}
+	}
+
+	buf := make([]int64, len(args.Scores))
+	for i := range args.Scores {
+		buf[i] = args.Scores[i] * common.MaxNodeScore / total
+	}
+
+	return NormalizeScoreResult{Scores: buf}
+}
+
+// testLegacyScoreServer is a score plugin built before NormalizeScore.
+type testLegacyScoreServer struct {
+	impl ScoreImpl
+}
+
+func (s *testLegacyScoreServer) Run(args *common.Args, resp *ScoreResult) error {
+	*resp = s.impl.Run(args)
+	return nil
+}
+
+// testCountScoreServer counts the NormalizeScore calls of a plugin.
+type testCountScoreServer struct {
+	ScoreRPCServer
+	calls int
+}
+
+func (s *testCountScoreServer) NormalizeScore(args *common.NormalizeArgs, resp *NormalizeScoreResult) error {
+	s.calls++
+	return s.ScoreRPCServer.NormalizeScore(args, resp)
+}
+
+func TestNormalizeScore(t *testing.T) {
+	helper := func(rcvr interface{}) *ScoreRPC {
+		srv := rpc.NewServer()
+		_ = srv.RegisterName("Plugin", rcvr)
+		c1, c2 := net.Pipe()
+		go srv.ServeConn(c1)
+		return &ScoreRPC{client: rpc.NewClient(c2)}
+	}
+
+	count := &testCountScoreServer{ScoreRPCServer: ScoreRPCServer{Impl: &testScore{}}}
+
+	p := plugin{
+		cfg:   DefaultConfig(),
+		hooks: map[string]bool{},
+		score: map[string]ScoreImpl{
+			"NormalizeScore": helper(&ScoreRPCServer{Impl: &testNormalizeScore{}}),
+			"Score":          helper(count),
+			"Legacy":         helper(&testLegacyScoreServer{impl: &testScore{}}),
+		},
+	}
+
+	defer func() {
+		for _, item := range p.score {
+			_ = item.(*ScoreRPC).client.Close()
+		}
+	}()
+
+	ctx := context.Background()
+	task := &common.Task{}
+	nodes := []*common.Node{{Name: "node1"}, {Name: "node2"}}
+
+	for name, impl := range p.score {
+		assert.Equal(t, nil, p.initHooks(ctx, TypeScore, name, impl))
+	}
+
+	_, err := p.RunNormalizeScore(ctx, "invalid", task, nodes, []int64{1, 3})
+	assert.NotEqual(t, nil, err)
+
+	// The plugins without NormalizeScore are not called
+	res, err := p.RunNormalizeScore(ctx, "Score", task, nodes, []int64{1, 3})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, NormalizeScoreResult{}, res)
+	assert.Equal(t, 0, count.calls)
+
+	res, err = p.RunNormalizeScore(ctx, "Legacy", task, nodes, []int64{1, 3})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, NormalizeScoreResult{}, res)
+
+	res, err = p.RunNormalizeScore(ctx, "NormalizeScore", task, nodes, []int64{0, 0})
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, "", res.Error)
+
+	res, err = p.RunNormalizeScore(ctx, "NormalizeScore", task, nodes, []int64{1, 3})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []int64{25, 75}, res.Scores)
+}
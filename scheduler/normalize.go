@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"math"
+
+	"github.com/pipego/scheduler/common"
+	"github.com/pipego/scheduler/config"
+)
+
+// normalizers map the raw scores of a score plugin on every node into
+// [MinNodeScore, MaxNodeScore] in place, before they are weighted.
+var normalizers = map[string]func([]int64){
+	config.NormalizeMinMax:  minMaxHelper,
+	config.NormalizeReverse: reverseHelper,
+}
+
+// minMaxHelper maps the lowest score to MinNodeScore and the highest one to
+// MaxNodeScore. The scores are all MaxNodeScore if equal.
+func minMaxHelper(scores []int64) {
+	scaleHelper(scores, false)
+}
+
+// reverseHelper maps the lowest score to MaxNodeScore and the highest one to
+// MinNodeScore, e.g. for latency or cost. The scores are all MaxNodeScore if
+// equal.
+func reverseHelper(scores []int64) {
+	scaleHelper(scores, true)
+}
+
+// scaleHelper maps scores linearly into [MinNodeScore, MaxNodeScore] by their
+// distance from the lowest score, or from the highest one if reversed. The
+// distances are taken in float64, since they may overflow int64.
+func scaleHelper(scores []int64, reverse bool) {
+	if len(scores) == 0 {
+		return
+	}
+
+	low, high := scores[0], scores[0]
+
+	for _, item := range scores {
+		if item < low {
+			low = item
+		}
+		if item > high {
+			high = item
+		}
+	}
+
+	for i := range scores {
+		if high == low {
+			scores[i] = common.MaxNodeScore
+			continue
+		}
+		r := (float64(scores[i]) - float64(low)) / (float64(high) - float64(low))
+		if reverse {
+			r = (float64(high) - float64(scores[i])) / (float64(high) - float64(low))
+		}
+		scores[i] = common.MinNodeScore + int64(math.Round(r*float64(common.MaxNodeScore-common.MinNodeScore)))
+	}
+}
@@ -0,0 +1,158 @@
+package scheduler
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pipego/scheduler/common"
+	"github.com/pipego/scheduler/config"
+	"github.com/pipego/scheduler/plugin"
+)
+
+// testScorePlugin scores each node by scores, e.g. a latency in ms, and divides
+// them by divisor in the NormalizeScore phase if set.
+type testScorePlugin struct {
+	testPlugin
+	scores  map[string]int64
+	divisor int64
+}
+
+func (p *testScorePlugin) RunScore(_ context.Context, _ string, _ *common.Task, node *common.Node) (plugin.ScoreResult, error) {
+	return plugin.ScoreResult{Score: p.scores[node.Name]}, nil
+}
+
+func (p *testScorePlugin) RunNormalizeScore(_ context.Context, _ string, _ *common.Task, _ []*common.Node,
+	scores []int64) (plugin.NormalizeScoreResult, error) {
+	if p.divisor < 0 {
+		return plugin.NormalizeScoreResult{Error: "invalid divisor"}, nil
+	}
+
+	if p.divisor == 0 {
+		return plugin.NormalizeScoreResult{}, nil
+	}
+
+	buf := make([]int64, len(scores))
+	for i := range scores {
+		buf[i] = scores[i] / p.divisor
+	}
+
+	return plugin.NormalizeScoreResult{Scores: buf}, nil
+}
+
+func TestMinMaxHelper(t *testing.T) {
+	var scores []int64
+
+	minMaxHelper(scores)
+	assert.Equal(t, 0, len(scores))
+
+	scores = []int64{-50, 150, 1000}
+	minMaxHelper(scores)
+	assert.Equal(t, []int64{0, 19, 100}, scores)
+
+	scores = []int64{5, 5}
+	minMaxHelper(scores)
+	assert.Equal(t, []int64{100, 100}, scores)
+}
+
+func TestReverseHelper(t *testing.T) {
+	scores := []int64{-50, 150, 1000}
+	reverseHelper(scores)
+	assert.Equal(t, []int64{100, 81, 0}, scores)
+
+	scores = []int64{5, 5}
+	reverseHelper(scores)
+	assert.Equal(t, []int64{100, 100}, scores)
+
+	scores = []int64{math.MinInt64, 0, math.MaxInt64}
+	reverseHelper(scores)
+	assert.Equal(t, []int64{100, 50, 0}, scores)
+}
+
+func TestNormalize(t *testing.T) {
+	ctx := context.Background()
+
+	helper := func(normalize string, divisor int64) (map[string]int64, error) {
+		c := config.Config{
+			Spec: config.Spec{
+				Score: config.Plugin{
+					Enabled: []config.Enabled{
+						{Name: "Latency", Normalize: normalize, Weight: 2},
+					},
+				},
+			},
+		}
+		s := scheduler{
+			cfg: &Config{
+				Config:       c,
+				Parallelizer: initParallelizer(&c),
+				Plugin: &testScorePlugin{
+					scores:  map[string]int64{"node1": 20, "node2": 200, "node3": 2000},
+					divisor: divisor,
+				},
+			},
+		}
+		nodes := []*common.Node{{Name: "node1"}, {Name: "node2"}, {Name: "node3"}}
+		scores, err := s.runScorePlugins(ctx, &common.Task{}, nodes)
+		buf := make(map[string]int64)
+		for _, item := range scores {
+			buf[item.name] = item.score
+		}
+		return buf, err
+	}
+
+	buf, err := helper("", 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]int64{"node1": 40}, buf)
+
+	buf, err = helper("", 20)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]int64{"node1": 2, "node2": 20, "node3": 200}, buf)
+
+	buf, err = helper("", -1)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]int64{}, buf)
+
+	// The built-in normalizers take precedence over the plugin
+	buf, err = helper(config.NormalizeMinMax, 20)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]int64{"node1": 0, "node2": 18, "node3": 200}, buf)
+
+	buf, err = helper(config.NormalizeReverse, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]int64{"node1": 200, "node2": 182, "node3": 0}, buf)
+}
+
+func TestExplainNormalize(t *testing.T) {
+	ctx := context.Background()
+
+	c := config.Config{
+		Spec: config.Spec{
+			Score: config.Plugin{
+				Enabled: []config.Enabled{
+					{Name: "Latency", Normalize: config.NormalizeReverse, Weight: 2},
+				},
+			},
+		},
+	}
+
+	s := scheduler{
+		cfg: &Config{
+			Config:       c,
+			Parallelizer: initParallelizer(&c),
+			Plugin:       &testScorePlugin{scores: map[string]int64{"node1": 20, "node2": 2000}},
+		},
+	}
+
+	nodes := []*common.Node{{Name: "node1"}, {Name: "node2"}}
+
+	buf := s.Explain(ctx, &common.Task{}, nodes)
+	assert.Equal(t, "", buf.Error)
+	assert.Equal(t, "node1", buf.Name)
+	assert.Equal(t, []PluginScore{{Plugin: "Latency", Score: 20, Normalized: 100, Weight: 2}}, buf.Nodes[0].Scores)
+	assert.Equal(t, int64(200), buf.Nodes[0].Total)
+	assert.Equal(t, []PluginScore{{Plugin: "Latency", Score: 2000, Normalized: 0, Weight: 2}}, buf.Nodes[1].Scores)
+	assert.Equal(t, int64(0), buf.Nodes[1].Total)
+}
@@ -2,7 +2,9 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
+	"slices"
 	"sort"
 	"sync"
 
@@ -17,7 +19,10 @@ import (
 type Scheduler interface {
 	Init(context.Context) error
 	Deinit(context.Context) error
+	Health(context.Context) error
 	Run(context.Context, *common.Task, []*common.Node) Result
+	RunBatch(context.Context, []*common.Task, []*common.Node) []Result
+	Explain(context.Context, *common.Task, []*common.Node) Explanation
 }
 
 type Config struct {
@@ -26,18 +31,92 @@ type Config struct {
 	Plugin       plugin.Plugin
 }
 
+type Code int
+
+const (
+	CodeOK Code = iota
+	CodeInvalidNodes
+	CodeFetchFailed
+	CodeFilterFailed
+	CodeUnschedulable
+	CodeScoreFailed
+	CodeSelectFailed
+	CodeUnavailable
+)
+
 type Result struct {
+	Name       string
+	Error      string
+	Code       Code
+	Details    string
+	Candidates []Candidate
+}
+
+// Candidate is a feasible node with its total score. Candidates are ranked
+// with the selected node first.
+type Candidate struct {
 	Name  string
-	Error string
+	Score int64
+}
+
+// Explanation is the result of a dry run with the verdicts of every node.
+type Explanation struct {
+	Result
+	Nodes []NodeExplanation
+	Ties  []string
+}
+
+type NodeExplanation struct {
+	Name     string
+	Feasible bool
+	Filters  []FilterVerdict
+	Scores   []PluginScore
+	Total    int64
+}
+
+type FilterVerdict struct {
+	Plugin string
+	Error  string
+}
+
+// PluginScore is the score of a plugin on a node, where Score is what the plugin
+// returned and Normalized is what is weighted.
+type PluginScore struct {
+	Plugin     string
+	Score      int64
+	Normalized int64
+	Weight     int64
 }
 
 type scheduler struct {
-	cfg *Config
+	cfg    *Config
+	mutex  sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+type filterStatus struct {
+	name   string
+	plugin string
+	error  string
+}
+
+// scoreStatus is the result of a score plugin on a node, which is neither
+// scored nor rejected if skipped.
+type scoreStatus struct {
+	raw      int64
+	score    int64
+	scored   bool
+	rejected bool
 }
 
 type nodeScore struct {
-	name  string
-	score int64
+	name       string
+	plugin     string
+	raw        int64
+	normalized int64
+	weight     int64
+	score      int64
 }
 
 func New(_ context.Context, cfg *Config) Scheduler {
@@ -51,6 +130,10 @@ func DefaultConfig() *Config {
 }
 
 func (s *scheduler) Init(ctx context.Context) error {
+	if err := s.validate(); err != nil {
+		return errors.Wrap(err, "failed to validate config")
+	}
+
 	if err := s.cfg.Parallelizer.Init(ctx, parallelizer.DefaultParallelism); err != nil {
 		return errors.Wrap(err, "failed to init parallelizer")
 	}
@@ -62,41 +145,327 @@ func (s *scheduler) Init(ctx context.Context) error {
 	return nil
 }
 
+// validate checks the values of the config which are only matched in cycles,
+// so that an invalid one fails the startup or reload instead.
+func (s *scheduler) validate() error {
+	switch s.cfg.Config.Spec.Filter.Mode {
+	case config.ModeAll, config.ModeFirstNonEmpty, "":
+	default:
+		return errors.New("invalid mode " + s.cfg.Config.Spec.Filter.Mode)
+	}
+
+	for _, item := range s.cfg.Config.Spec.Score.Enabled {
+		if _, ok := normalizers[item.Normalize]; !ok && item.Normalize != "" {
+			return errors.New("invalid normalize " + item.Normalize)
+		}
+	}
+
+	for _, pl := range [][]config.Enabled{s.cfg.Config.Spec.Fetch.Enabled, s.cfg.Config.Spec.Filter.Enabled,
+		s.cfg.Config.Spec.Score.Enabled} {
+		for _, item := range pl {
+			switch item.Policy {
+			case config.PolicyReject, config.PolicySkip, "":
+			default:
+				return errors.New("invalid policy " + item.Policy)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Deinit stops accepting cycles, and waits for the cycles in flight to finish
+// before the plugins are killed, unless ctx is done first.
 func (s *scheduler) Deinit(ctx context.Context) error {
+	s.mutex.Lock()
+	s.closed = true
+	s.mutex.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
 	return s.cfg.Plugin.Deinit(ctx)
 }
 
+func (s *scheduler) Health(ctx context.Context) error {
+	return s.cfg.Plugin.Health(ctx)
+}
+
 func (s *scheduler) Run(ctx context.Context, task *common.Task, nodes []*common.Node) Result {
-	var scores []nodeScore
+	if !s.acquire() {
+		return Result{Error: "scheduler is closed", Code: CodeUnavailable}
+	}
+
+	defer s.release()
+
+	if len(nodes) == 0 {
+		return Result{Error: "invalid nodes", Code: CodeInvalidNodes}
+	}
+
+	_, rejected, err := s.runFetchPlugins(ctx, nodes)
+	if err != nil {
+		return Result{Error: "failed to fetch", Code: CodeFetchFailed, Details: err.Error()}
+	}
+
+	return s.schedule(ctx, task, nodes, rejected)
+}
+
+// RunBatch places tasks in order against one node set. Fetch plugins run once
+// per batch, and each placement is reserved on the node before the next task
+// is scheduled, so that the batch never over-commits a node.
+func (s *scheduler) RunBatch(ctx context.Context, tasks []*common.Task, nodes []*common.Node) []Result {
+	helper := func(r Result) []Result {
+		b := make([]Result, len(tasks))
+		for i := range b {
+			b[i] = r
+		}
+		return b
+	}
+
+	if !s.acquire() {
+		return helper(Result{Error: "scheduler is closed", Code: CodeUnavailable})
+	}
+
+	defer s.release()
+
+	if len(nodes) == 0 {
+		return helper(Result{Error: "invalid nodes", Code: CodeInvalidNodes})
+	}
+
+	_, rejected, err := s.runFetchPlugins(ctx, nodes)
+	if err != nil {
+		return helper(Result{Error: "failed to fetch", Code: CodeFetchFailed, Details: err.Error()})
+	}
+
+	buf := make([]Result, len(tasks))
+
+	for i := range tasks {
+		buf[i] = s.schedule(ctx, tasks[i], nodes, rejected)
+		if buf[i].Error == "" {
+			s.reserve(ctx, tasks[i], nodes, buf[i].Name)
+		}
+	}
+
+	return buf
+}
+
+// Explain runs the same cycle as Run, and reports why each node is accepted,
+// rejected or preferred.
+func (s *scheduler) Explain(ctx context.Context, task *common.Task, nodes []*common.Node) Explanation {
+	if !s.acquire() {
+		return Explanation{Result: Result{Error: "scheduler is closed", Code: CodeUnavailable}}
+	}
+
+	defer s.release()
 
 	if len(nodes) == 0 {
-		return Result{Error: "invalid nodes"}
+		return Explanation{Result: Result{Error: "invalid nodes", Code: CodeInvalidNodes}}
+	}
+
+	_, rejected, err := s.runFetchPlugins(ctx, nodes)
+	if err != nil {
+		return Explanation{Result: Result{Error: "failed to fetch", Code: CodeFetchFailed, Details: err.Error()}}
+	}
+
+	return s.explain(ctx, task, nodes, rejected)
+}
+
+// acquire counts a cycle in flight, and fails once Deinit is called.
+func (s *scheduler) acquire() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	s.wg.Add(1)
+
+	return true
+}
+
+func (s *scheduler) release() {
+	s.wg.Done()
+}
+
+func (s *scheduler) schedule(ctx context.Context, task *common.Task, nodes []*common.Node, rejected []filterStatus) Result {
+	return s.explain(ctx, task, nodes, rejected).Result
+}
+
+// explain schedules task on nodes, less the ones rejected by fetch, which are
+// reported with the filter verdicts of the others.
+func (s *scheduler) explain(ctx context.Context, task *common.Task, nodes []*common.Node, rejected []filterStatus) Explanation {
+	buf := make([]NodeExplanation, len(nodes))
+	index := make(map[string]int, len(nodes))
+
+	for i := range nodes {
+		buf[i].Name = nodes[i].Name
+		index[nodes[i].Name] = i
+	}
+
+	for _, item := range rejected {
+		n := &buf[index[item.name]]
+		n.Filters = append(n.Filters, FilterVerdict{Plugin: item.plugin, Error: item.error})
+	}
+
+	fetched := make([]*common.Node, 0, len(nodes))
+
+	for i := range nodes {
+		if len(buf[i].Filters) == 0 {
+			fetched = append(fetched, nodes[i])
+		}
 	}
 
-	nodes, err := s.runFetchPlugins(ctx, nodes)
+	ctx = s.withCycle(ctx, task, fetched)
+	exp := Explanation{Nodes: buf}
+
+	reason, err := s.runPreFilterPlugins(ctx, task)
 	if err != nil {
-		return Result{Error: "failed to fetch"}
+		exp.Result = Result{Error: "failed to filter", Code: CodeFilterFailed, Details: err.Error()}
+		return exp
+	}
+
+	if reason != "" {
+		exp.Result = Result{Error: "failed to filter", Code: CodeUnschedulable, Details: reason}
+		return exp
+	}
+
+	feasible, status, err := s.runFilterPlugins(ctx, task, fetched)
+	for _, item := range status {
+		n := &buf[index[item.name]]
+		n.Filters = append(n.Filters, FilterVerdict{Plugin: item.plugin, Error: item.error})
 	}
 
-	nodes, err = s.runFilterPlugins(ctx, task, nodes)
 	if err != nil {
-		return Result{Error: "failed to filter"}
+		exp.Result = Result{Error: "failed to filter", Code: CodeFilterFailed, Details: err.Error()}
+		return exp
+	}
+
+	if len(feasible) == 0 {
+		exp.Result = Result{Error: "failed to filter", Code: CodeUnschedulable, Details: s.unschedulable(ctx, nodes, slices.Concat(rejected, status))}
+		return exp
+	}
+
+	for _, item := range feasible {
+		buf[index[item.Name]].Feasible = true
 	}
 
-	scores, err = s.runScorePlugins(ctx, task, nodes)
+	reason, err = s.runPreScorePlugins(ctx, task, feasible)
 	if err != nil {
-		return Result{Error: "failed to score"}
+		exp.Result = Result{Error: "failed to score", Code: CodeScoreFailed, Details: err.Error()}
+		return exp
+	}
+
+	if reason != "" {
+		exp.Result = Result{Error: "failed to score", Code: CodeUnschedulable, Details: reason}
+		return exp
+	}
+
+	scores, err := s.runScorePlugins(ctx, task, feasible)
+	for _, item := range scores {
+		n := &buf[index[item.name]]
+		n.Scores = append(n.Scores, PluginScore{
+			Plugin:     item.plugin,
+			Score:      item.raw,
+			Normalized: item.normalized,
+			Weight:     item.weight,
+		})
+		n.Total += item.score
+	}
+
+	for i := range buf {
+		sort.Slice(buf[i].Scores, func(m, n int) bool {
+			return buf[i].Scores[m].Plugin < buf[i].Scores[n].Plugin
+		})
+	}
+
+	if err != nil {
+		exp.Result = Result{Error: "failed to score", Code: CodeScoreFailed, Details: err.Error()}
+		return exp
 	}
 
 	host, err := s.selectHost(ctx, scores)
 	if err != nil {
-		return Result{Error: "failed to select"}
+		exp.Result = Result{Error: "failed to select", Code: CodeSelectFailed, Details: err.Error()}
+		return exp
+	}
+
+	exp.Result = Result{Name: host, Candidates: s.rankCandidates(ctx, scores, host)}
+	exp.Ties = s.tieHosts(ctx, scores)
+
+	return exp
+}
+
+// withCycle scopes ctx to the scheduling cycle of task. The request ID is
+// inherited from the cycle of the caller, if any.
+func (s *scheduler) withCycle(ctx context.Context, task *common.Task, nodes []*common.Node) context.Context {
+	var id string
+
+	if c, ok := common.CycleFrom(ctx); ok {
+		if c.Task == task {
+			return ctx
+		}
+		id = c.ID
+	}
+
+	return common.WithCycle(ctx, common.NewCycle(id, task, nodes))
+}
+
+// unschedulable summarizes the filter rejections, e.g.
+// "0/3 nodes are available: NodeName(2), NodeAffinity(3)".
+func (s *scheduler) unschedulable(_ context.Context, nodes []*common.Node, status []filterStatus) string {
+	var plugins []string
+
+	count := make(map[string]int)
+
+	for _, item := range status {
+		if item.error == "" {
+			continue
+		}
+		if _, ok := count[item.plugin]; !ok {
+			plugins = append(plugins, item.plugin)
+		}
+		count[item.plugin]++
+	}
+
+	buf := fmt.Sprintf("0/%d nodes are available", len(nodes))
+
+	for i, item := range plugins {
+		if i == 0 {
+			buf += ": "
+		} else {
+			buf += ", "
+		}
+		buf += fmt.Sprintf("%s(%d)", item, count[item])
 	}
 
-	return Result{Name: host}
+	return buf
+}
+
+// reserve adds the requested resource of task to the selected node.
+func (s *scheduler) reserve(_ context.Context, task *common.Task, nodes []*common.Node, name string) {
+	for _, item := range nodes {
+		if item.Name == name {
+			item.RequestedResource.MilliCPU += task.RequestedResource.MilliCPU
+			item.RequestedResource.Memory += task.RequestedResource.Memory
+			item.RequestedResource.Storage += task.RequestedResource.Storage
+			return
+		}
+	}
 }
 
-func (s *scheduler) runFetchPlugins(ctx context.Context, nodes []*common.Node) ([]*common.Node, error) {
+// runFetchPlugins updates the resources of nodes by the fetch plugin, and returns
+// the nodes fetched along with the status of the ones rejected by its policy.
+func (s *scheduler) runFetchPlugins(ctx context.Context, nodes []*common.Node) ([]*common.Node, []filterStatus, error) {
 	helper := func(node *common.Node, res plugin.FetchResult) *common.Node {
 		if res.AllocatableResource.MilliCPU <= 0 &&
 			res.AllocatableResource.Memory <= 0 &&
@@ -116,74 +485,196 @@ func (s *scheduler) runFetchPlugins(ctx context.Context, nodes []*common.Node) (
 	}
 
 	if len(s.cfg.Config.Spec.Fetch.Enabled) == 0 {
-		return nodes, nil
+		return nodes, nil, nil
 	}
 
 	if len(s.cfg.Config.Spec.Fetch.Enabled) > 1 {
-		return nil, errors.New("invalid enabled")
+		return nil, nil, errors.New("invalid enabled")
 	}
 
 	pl := s.cfg.Config.Spec.Fetch.Enabled[0]
+	errs := make([]error, len(nodes))
 
 	parallelizer.ParallelizeUntil(ctx, parallelizer.DefaultParallelism, len(nodes), func(index int) {
 		if res, err := s.cfg.Plugin.RunFetch(ctx, pl.Name, nodes[index].Host); err == nil {
 			nodes[index] = helper(nodes[index], res)
+		} else if policyHelper(pl, config.PolicySkip) == config.PolicyReject {
+			errs[index] = err
+		}
+	})
+
+	buf := make([]*common.Node, 0, len(nodes))
+
+	var status []filterStatus
+
+	for i := range nodes {
+		if errs[i] != nil {
+			status = append(status, filterStatus{name: nodes[i].Name, plugin: pl.Name, error: errs[i].Error()})
+		} else {
+			buf = append(buf, nodes[i])
 		}
+	}
+
+	return buf, status, nil
+}
+
+// runPreFilterPlugins runs the PreFilter phase of the filter plugins once per
+// cycle, and returns the reason of the first plugin by priority which rejects
+// the task, if any.
+func (s *scheduler) runPreFilterPlugins(ctx context.Context, task *common.Task) (string, error) {
+	pl := priorityHelper(s.cfg.Config.Spec.Filter.Enabled)
+
+	return s.runPrePlugins(ctx, "PreFilter", pl, config.PolicyReject, func(name string) (string, error) {
+		res, err := s.cfg.Plugin.RunPreFilter(ctx, name, task)
+		return res.Error, err
+	})
+}
+
+// runPreScorePlugins is runPreFilterPlugins for the score plugins, which is run
+// with the feasible nodes.
+func (s *scheduler) runPreScorePlugins(ctx context.Context, task *common.Task, nodes []*common.Node) (string, error) {
+	pl := s.cfg.Config.Spec.Score.Enabled
+
+	return s.runPrePlugins(ctx, "PreScore", pl, config.PolicySkip, func(name string) (string, error) {
+		res, err := s.cfg.Plugin.RunPreScore(ctx, name, task, nodes)
+		return res.Error, err
+	})
+}
+
+// runPrePlugins runs fn for the plugins in parallel. A plugin whose call fails
+// is handled by its policy, which is def if unset.
+func (s *scheduler) runPrePlugins(ctx context.Context, phase string, pl []config.Enabled, def string,
+	fn func(string) (string, error)) (string, error) {
+	type preStatus struct {
+		reason string
+		err    error
+	}
+
+	status := make([]preStatus, len(pl))
+
+	s.cfg.Parallelizer.Until(ctx, len(pl), func(index int) {
+		reason, err := fn(pl[index].Name)
+		status[index] = preStatus{reason: reason, err: err}
 	})
 
-	return nodes, nil
+	if err := ctx.Err(); err != nil {
+		return "", errors.Wrap(err, "failed to run "+phase)
+	}
+
+	for i, item := range status {
+		if item.err != nil && policyHelper(pl[i], def) == config.PolicyReject {
+			return "", errors.Wrap(item.err, "failed to run "+phase+" "+pl[i].Name)
+		}
+		if item.err == nil && item.reason != "" {
+			return fmt.Sprintf("%s %s: %s", phase, pl[i].Name, item.reason), nil
+		}
+	}
+
+	return "", nil
 }
 
-func (s *scheduler) runFilterPlugins(ctx context.Context, task *common.Task, nodes []*common.Node) ([]*common.Node, error) {
+// runFilterPlugins returns the nodes passing the filter plugins in priority
+// order. In ModeAll a node must pass every plugin, and is not checked further
+// once rejected. In ModeFirstNonEmpty, the default, the nodes passing the first
+// plugin which accepts any are returned.
+func (s *scheduler) runFilterPlugins(ctx context.Context, task *common.Task,
+	nodes []*common.Node) ([]*common.Node, []filterStatus, error) {
 	var buf []*common.Node
+	var status []filterStatus
 
-	helper := func(p string, t *common.Task, n []*common.Node) []*common.Node {
-		var b []*common.Node
-		for i := range n {
-			if res, err := s.cfg.Plugin.RunFilter(ctx, p, t, n[i]); err == nil {
-				if res.Error == "" {
-					b = append(b, n[i])
-				}
+	helper := func(c config.Enabled, t *common.Task, n *common.Node) filterStatus {
+		res, err := s.cfg.Plugin.RunFilter(ctx, c.Name, t, n)
+		if err != nil && policyHelper(c, config.PolicyReject) == config.PolicyReject {
+			res.Error = err.Error()
+		}
+		return filterStatus{name: n.Name, plugin: c.Name, error: res.Error}
+	}
+
+	// filter checks the nodes in parallel, and collects the ones passing along
+	// with their status in node order. A node passes if its last status does.
+	filter := func(check func(n *common.Node) []filterStatus) {
+		b := make([][]filterStatus, len(nodes))
+		s.cfg.Parallelizer.Until(ctx, len(nodes), func(index int) {
+			b[index] = check(nodes[index])
+		})
+		for i := range nodes {
+			status = append(status, b[i]...)
+			if len(b[i]) != 0 && b[i][len(b[i])-1].error == "" {
+				buf = append(buf, nodes[i])
 			}
 		}
-		return b
 	}
 
 	if len(s.cfg.Config.Spec.Filter.Enabled) == 0 {
-		return nodes, nil
+		return nodes, nil, nil
 	}
 
-	pl := s.cfg.Config.Spec.Filter.Enabled
-	sort.Slice(pl, func(i, j int) bool {
-		return pl[i].Priority < pl[j].Priority
-	})
+	pl := priorityHelper(s.cfg.Config.Spec.Filter.Enabled)
 
-	for _, item := range pl {
-		buf = helper(item.Name, task, nodes)
-		if len(buf) != 0 {
-			break
+	switch s.cfg.Config.Spec.Filter.Mode {
+	case config.ModeAll:
+		filter(func(n *common.Node) []filterStatus {
+			var b []filterStatus
+			for _, item := range pl {
+				if b = append(b, helper(item, task, n)); b[len(b)-1].error != "" {
+					break
+				}
+			}
+			return b
+		})
+	case config.ModeFirstNonEmpty, "":
+		for _, item := range pl {
+			filter(func(n *common.Node) []filterStatus {
+				return []filterStatus{helper(item, task, n)}
+			})
+			if len(buf) != 0 {
+				break
+			}
 		}
+	default:
+		return nil, status, errors.New("invalid mode " + s.cfg.Config.Spec.Filter.Mode)
 	}
 
-	return buf, nil
+	return buf, status, nil
 }
 
+// runScorePlugins runs every (plugin, node) pair in parallel. Each pair writes
+// its own slot of the per-plugin slices, so that no lock is needed.
 func (s *scheduler) runScorePlugins(ctx context.Context, task *common.Task, nodes []*common.Node) ([]nodeScore, error) {
 	var buf []nodeScore
 
-	helper := func(c config.Enabled, t *common.Task, n []*common.Node) []nodeScore {
-		var b []nodeScore
-		for i := range n {
-			if res, err := s.cfg.Plugin.RunScore(ctx, c.Name, t, n[i]); err == nil {
-				if res.Score >= common.MinNodeScore && res.Score <= common.MaxNodeScore {
-					b = append(b, nodeScore{
-						name:  n[i].Name,
-						score: res.Score * c.Weight,
-					})
-				}
+	failHelper := func(c config.Enabled) scoreStatus {
+		return scoreStatus{rejected: policyHelper(c, config.PolicySkip) == config.PolicyReject}
+	}
+
+	helper := func(c config.Enabled, t *common.Task, n *common.Node) scoreStatus {
+		res, err := s.cfg.Plugin.RunScore(ctx, c.Name, t, n)
+		if err != nil {
+			return failHelper(c)
+		}
+		return scoreStatus{raw: res.Score, score: res.Score, scored: true}
+	}
+
+	// normalize is the built-in normalizer of the enabled plugin if configured,
+	// or else its NormalizeScore phase.
+	normalize := func(c config.Enabled) func([]*common.Node, []int64) error {
+		if c.Normalize != "" {
+			return func(_ []*common.Node, scores []int64) error {
+				normalizers[c.Normalize](scores)
+				return nil
 			}
 		}
-		return b
+		return func(n []*common.Node, scores []int64) error {
+			res, err := s.cfg.Plugin.RunNormalizeScore(ctx, c.Name, task, n, scores)
+			if err != nil {
+				return err
+			}
+			if res.Error != "" {
+				return errors.New(res.Error)
+			}
+			copy(scores, res.Scores)
+			return nil
+		}
 	}
 
 	if len(s.cfg.Config.Spec.Score.Enabled) == 0 {
@@ -191,57 +682,182 @@ func (s *scheduler) runScorePlugins(ctx context.Context, task *common.Task, node
 	}
 
 	pl := s.cfg.Config.Spec.Score.Enabled
-	m := sync.Mutex{}
 
-	parallelizer.ParallelizeUntil(ctx, parallelizer.DefaultParallelism, len(pl), func(index int) {
-		m.Lock()
-		defer m.Unlock()
-		b := helper(pl[index], task, nodes)
-		if len(b) != 0 {
-			buf = append(buf, b...)
+	status := make([][]scoreStatus, len(pl))
+	for i := range status {
+		status[i] = make([]scoreStatus, len(nodes))
+	}
+
+	s.cfg.Parallelizer.Until(ctx, len(pl)*len(nodes), func(index int) {
+		i, j := index/len(nodes), index%len(nodes)
+		status[i][j] = helper(pl[i], task, nodes[j])
+	})
+
+	// rejectHelper returns the nodes rejected by any plugin
+	rejectHelper := func() []bool {
+		b := make([]bool, len(nodes))
+		for i := range status {
+			for j := range status[i] {
+				b[j] = b[j] || status[i][j].rejected
+			}
+		}
+		return b
+	}
+
+	rejected := rejectHelper()
+
+	// A plugin failing to normalize fails on every node it scored
+	s.cfg.Parallelizer.Until(ctx, len(pl), func(i int) {
+		if err := normalizeHelper(normalize(pl[i]), nodes, status[i], rejected); err != nil {
+			for j := range status[i] {
+				if status[i][j].scored {
+					status[i][j] = failHelper(pl[i])
+				}
+			}
 		}
 	})
 
+	rejected = rejectHelper()
+
+	// Scores out of range once normalized are skipped
+	for i := range status {
+		for j, item := range status[i] {
+			if !item.scored || rejected[j] || item.score < common.MinNodeScore || item.score > common.MaxNodeScore {
+				continue
+			}
+			buf = append(buf, nodeScore{
+				name:       nodes[j].Name,
+				plugin:     pl[i].Name,
+				raw:        item.raw,
+				normalized: item.score,
+				weight:     pl[i].Weight,
+				score:      item.score * pl[i].Weight,
+			})
+		}
+	}
+
 	return buf, nil
 }
 
-// nolint: gosec
-func (s *scheduler) selectHost(_ context.Context, scores []nodeScore) (string, error) {
-	helper := func(s []nodeScore) map[string]int64 {
-		b := make(map[string]int64)
-		for _, item := range s {
-			if _, ok := b[item.name]; ok {
-				b[item.name] += item.score
-			} else {
-				b[item.name] = item.score
-			}
+// normalizeHelper normalizes the scores of a plugin on the nodes scored and not
+// rejected.
+func normalizeHelper(normalize func([]*common.Node, []int64) error, nodes []*common.Node, status []scoreStatus,
+	rejected []bool) error {
+	var index []int
+	var buf []*common.Node
+	var scores []int64
+
+	for i := range status {
+		if status[i].scored && !rejected[i] {
+			index = append(index, i)
+			buf = append(buf, nodes[i])
+			scores = append(scores, status[i].score)
 		}
-		return b
 	}
 
+	if len(index) == 0 {
+		return nil
+	}
+
+	if err := normalize(buf, scores); err != nil {
+		return err
+	}
+
+	for i := range index {
+		status[index[i]].score = scores[i]
+	}
+
+	return nil
+}
+
+// priorityHelper returns a copy of the enabled plugins sorted by priority, since
+// the config is shared by concurrent cycles.
+func priorityHelper(enabled []config.Enabled) []config.Enabled {
+	pl := make([]config.Enabled, len(enabled))
+	copy(pl, enabled)
+
+	sort.SliceStable(pl, func(i, j int) bool {
+		return pl[i].Priority < pl[j].Priority
+	})
+
+	return pl
+}
+
+// policyHelper returns the policy of the enabled plugin, or def if unset.
+func policyHelper(c config.Enabled, def string) string {
+	if c.Policy == "" {
+		return def
+	}
+
+	return c.Policy
+}
+
+// nolint: gosec
+func (s *scheduler) selectHost(ctx context.Context, scores []nodeScore) (string, error) {
 	if len(scores) == 0 {
 		return "", errors.New("invalid scores")
 	}
 
-	buf := helper(scores)
+	buf := s.tieHosts(ctx, scores)
 
-	count := 1
-	_max := int64(-1)
-	selected := ""
+	// Break the tie uniformly at random
+	return buf[rand.Intn(len(buf))], nil
+}
 
-	for key, val := range buf {
-		if val > _max {
-			_max = val
-			selected = key
-			count = 1
-		} else if val == _max {
-			count++
-			if rand.Intn(count) == 0 {
-				// Replace the candidate with probability of 1/count
-				selected = key
-			}
+// rankHosts sums the weighted scores of each node, and sorts the nodes by
+// total score in descending order, then by name.
+func (s *scheduler) rankHosts(_ context.Context, scores []nodeScore) []nodeScore {
+	var buf []nodeScore
+
+	index := make(map[string]int)
+
+	for _, item := range scores {
+		if i, ok := index[item.name]; ok {
+			buf[i].score += item.score
+		} else {
+			index[item.name] = len(buf)
+			buf = append(buf, nodeScore{name: item.name, score: item.score})
+		}
+	}
+
+	sort.Slice(buf, func(i, j int) bool {
+		if buf[i].score != buf[j].score {
+			return buf[i].score > buf[j].score
+		}
+		return buf[i].name < buf[j].name
+	})
+
+	return buf
+}
+
+// rankCandidates returns the ranked nodes with the selected host first.
+func (s *scheduler) rankCandidates(ctx context.Context, scores []nodeScore, host string) []Candidate {
+	ranked := s.rankHosts(ctx, scores)
+	buf := make([]Candidate, 0, len(ranked))
+
+	for _, item := range ranked {
+		if item.name == host {
+			buf = append([]Candidate{{Name: item.name, Score: item.score}}, buf...)
+		} else {
+			buf = append(buf, Candidate{Name: item.name, Score: item.score})
+		}
+	}
+
+	return buf
+}
+
+// tieHosts returns the nodes sharing the highest total score.
+func (s *scheduler) tieHosts(ctx context.Context, scores []nodeScore) []string {
+	var buf []string
+
+	ranked := s.rankHosts(ctx, scores)
+
+	for _, item := range ranked {
+		if item.score != ranked[0].score {
+			break
 		}
+		buf = append(buf, item.name)
 	}
 
-	return selected, nil
+	return buf
 }
@@ -2,7 +2,11 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -55,7 +59,7 @@ func TestRunFetchPlugins(t *testing.T) {
 	}
 
 	_ = s.Init(ctx)
-	_, err := s.runFetchPlugins(ctx, nodes)
+	_, _, err := s.runFetchPlugins(ctx, nodes)
 	assert.Equal(t, nil, err)
 	_ = s.Deinit(ctx)
 
@@ -79,7 +83,7 @@ func TestRunFetchPlugins(t *testing.T) {
 	}
 
 	_ = s.Init(ctx)
-	_, err = s.runFetchPlugins(ctx, nodes)
+	_, _, err = s.runFetchPlugins(ctx, nodes)
 	assert.NotEqual(t, nil, err)
 	_ = s.Deinit(ctx)
 
@@ -100,7 +104,7 @@ func TestRunFetchPlugins(t *testing.T) {
 
 	_ = s.Init(ctx)
 	nodes = append(nodes, &common.Node{Host: "127.0.0.1"})
-	buf, err := s.runFetchPlugins(ctx, nodes)
+	buf, _, err := s.runFetchPlugins(ctx, nodes)
 	assert.Equal(t, nil, err)
 	assert.Equal(t, int64(100), buf[0].AllocatableResource.MilliCPU)
 	_ = s.Deinit(ctx)
@@ -120,7 +124,7 @@ func TestRunFilterPlugins(t *testing.T) {
 	}
 
 	_ = s.Init(ctx)
-	_, err := s.runFilterPlugins(ctx, &task, nodes)
+	_, _, err := s.runFilterPlugins(ctx, &task, nodes)
 	assert.Equal(t, nil, err)
 	_ = s.Deinit(ctx)
 
@@ -147,9 +151,12 @@ func TestRunFilterPlugins(t *testing.T) {
 
 	_ = s.Init(ctx)
 	nodes = append(nodes, &common.Node{Host: "127.0.0.1"})
-	buf, err := s.runFilterPlugins(ctx, &task, nodes)
+	buf, status, err := s.runFilterPlugins(ctx, &task, nodes)
 	assert.Equal(t, nil, err)
 	assert.Equal(t, 1, len(buf))
+	assert.Equal(t, 1, len(status))
+	assert.Equal(t, "NodeName", status[0].plugin)
+	assert.Equal(t, "", status[0].error)
 	_ = s.Deinit(ctx)
 }
 
@@ -236,3 +243,769 @@ func TestSelectHost(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, "", buf)
 }
+
+func TestRunBatch(t *testing.T) {
+	var tasks []*common.Task
+	var nodes []*common.Node
+	ctx := context.Background()
+
+	c := config.Config{
+		Spec: config.Spec{
+			Filter: config.Plugin{
+				Enabled: []config.Enabled{
+					{
+						Name:     "NodeName",
+						Path:     "../filter-nodename",
+						Priority: 1,
+					},
+				},
+			},
+			Score: config.Plugin{
+				Enabled: []config.Enabled{
+					{
+						Name:   "NodeResourcesFit",
+						Path:   "../score-noderesourcesfit",
+						Weight: 1,
+					},
+				},
+			},
+		},
+	}
+
+	s := scheduler{
+		cfg: &Config{
+			Config:       c,
+			Parallelizer: initParallelizer(&c),
+			Plugin:       initPlugin(&c),
+		},
+	}
+
+	_ = s.Init(ctx)
+
+	tasks = append(tasks, &common.Task{Name: "task1"}, &common.Task{Name: "task2"})
+
+	buf := s.RunBatch(ctx, tasks, nodes)
+	assert.Equal(t, 2, len(buf))
+	assert.Equal(t, "invalid nodes", buf[0].Error)
+	assert.Equal(t, "invalid nodes", buf[1].Error)
+
+	nodes = append(nodes, &common.Node{Name: "node1", Host: "127.0.0.1"})
+
+	buf = s.RunBatch(ctx, tasks, nodes)
+	assert.Equal(t, 2, len(buf))
+	assert.Equal(t, "node1", buf[0].Name)
+	assert.Equal(t, "node1", buf[1].Name)
+
+	_ = s.Deinit(ctx)
+}
+
+// testFitPlugin rejects the nodes without enough CPU left for the task in the
+// NodeResourcesFit filter.
+type testFitPlugin struct {
+	testPlugin
+}
+
+func (p *testFitPlugin) RunFilter(_ context.Context, _ string, task *common.Task, node *common.Node) (plugin.FilterResult, error) {
+	if node.RequestedResource.MilliCPU+task.RequestedResource.MilliCPU > node.AllocatableResource.MilliCPU {
+		return plugin.FilterResult{Error: "Insufficient cpu"}, nil
+	}
+
+	return plugin.FilterResult{}, nil
+}
+
+func TestRunBatchFit(t *testing.T) {
+	ctx := context.Background()
+
+	c := config.Config{
+		Spec: config.Spec{
+			Filter: config.Plugin{
+				Enabled: []config.Enabled{{Name: "NodeResourcesFit"}},
+			},
+			Score: config.Plugin{
+				Enabled: []config.Enabled{{Name: "Score", Weight: 1}},
+			},
+		},
+	}
+
+	s := scheduler{
+		cfg: &Config{
+			Config:       c,
+			Parallelizer: initParallelizer(&c),
+			Plugin:       &testFitPlugin{},
+		},
+	}
+
+	// The tasks fit on a node one at a time, but not together
+	tasks := []*common.Task{
+		{Name: "task1", RequestedResource: common.Resource{MilliCPU: 600}},
+		{Name: "task2", RequestedResource: common.Resource{MilliCPU: 600}},
+	}
+
+	helper := func(names ...string) []*common.Node {
+		var b []*common.Node
+		for _, item := range names {
+			b = append(b, &common.Node{Name: item, AllocatableResource: common.Resource{MilliCPU: 1000}})
+		}
+		return b
+	}
+
+	buf := s.RunBatch(ctx, tasks, helper("node1", "node2"))
+	assert.Equal(t, 2, len(buf))
+	assert.Equal(t, "", buf[0].Error)
+	assert.Equal(t, "", buf[1].Error)
+	assert.NotEqual(t, buf[0].Name, buf[1].Name)
+
+	buf = s.RunBatch(ctx, tasks, helper("node1"))
+	assert.Equal(t, 2, len(buf))
+	assert.Equal(t, "node1", buf[0].Name)
+	assert.Equal(t, "", buf[1].Name)
+	assert.Equal(t, CodeUnschedulable, buf[1].Code)
+}
+
+func TestReserve(t *testing.T) {
+	ctx := context.Background()
+
+	s := scheduler{}
+
+	task := common.Task{
+		RequestedResource: common.Resource{
+			MilliCPU: 1,
+			Memory:   2,
+			Storage:  3,
+		},
+	}
+
+	nodes := []*common.Node{
+		{Name: "node1"},
+		{Name: "node2"},
+	}
+
+	s.reserve(ctx, &task, nodes, "invalid")
+	assert.Equal(t, int64(0), nodes[0].RequestedResource.MilliCPU)
+	assert.Equal(t, int64(0), nodes[1].RequestedResource.MilliCPU)
+
+	s.reserve(ctx, &task, nodes, "node2")
+	s.reserve(ctx, &task, nodes, "node2")
+	assert.Equal(t, int64(0), nodes[0].RequestedResource.MilliCPU)
+	assert.Equal(t, int64(2), nodes[1].RequestedResource.MilliCPU)
+	assert.Equal(t, int64(4), nodes[1].RequestedResource.Memory)
+	assert.Equal(t, int64(6), nodes[1].RequestedResource.Storage)
+}
+
+func TestExplain(t *testing.T) {
+	var nodes []*common.Node
+	ctx := context.Background()
+
+	c := config.Config{
+		Spec: config.Spec{
+			Filter: config.Plugin{
+				Enabled: []config.Enabled{
+					{
+						Name:     "NodeName",
+						Path:     "../filter-nodename",
+						Priority: 1,
+					},
+				},
+			},
+			Score: config.Plugin{
+				Enabled: []config.Enabled{
+					{
+						Name:   "NodeResourcesFit",
+						Path:   "../score-noderesourcesfit",
+						Weight: 2,
+					},
+					{
+						Name:   "NodeResourcesBalancedAllocation",
+						Path:   "../score-noderesourcesbalancedallocation",
+						Weight: 1,
+					},
+				},
+			},
+		},
+	}
+
+	s := scheduler{
+		cfg: &Config{
+			Config:       c,
+			Parallelizer: initParallelizer(&c),
+			Plugin:       initPlugin(&c),
+		},
+	}
+
+	_ = s.Init(ctx)
+
+	buf := s.Explain(ctx, &common.Task{}, nodes)
+	assert.Equal(t, "invalid nodes", buf.Error)
+
+	nodes = append(nodes, &common.Node{Name: "node1", Host: "127.0.0.1"}, &common.Node{Name: "node2", Host: "127.0.0.1"})
+
+	buf = s.Explain(ctx, &common.Task{}, nodes)
+	assert.Equal(t, "", buf.Error)
+	assert.NotEqual(t, "", buf.Name)
+	assert.Equal(t, []string{"node1", "node2"}, buf.Ties)
+	assert.Equal(t, 2, len(buf.Nodes))
+	assert.Equal(t, true, buf.Nodes[0].Feasible)
+	assert.Equal(t, []FilterVerdict{{Plugin: "NodeName"}}, buf.Nodes[0].Filters)
+	assert.Equal(t, []PluginScore{
+		{Plugin: "NodeResourcesBalancedAllocation", Weight: 1},
+		{Plugin: "NodeResourcesFit", Weight: 2},
+	}, buf.Nodes[0].Scores)
+
+	_ = s.Deinit(ctx)
+}
+
+func TestTieHosts(t *testing.T) {
+	ctx := context.Background()
+
+	s := scheduler{}
+
+	buf := s.tieHosts(ctx, nil)
+	assert.Equal(t, 0, len(buf))
+
+	scores := []nodeScore{
+		{name: "name2", score: 2},
+		{name: "name1", score: 1},
+		{name: "name3", score: 1},
+		{name: "name1", score: 1},
+	}
+
+	buf = s.tieHosts(ctx, scores)
+	assert.Equal(t, []string{"name1", "name2"}, buf)
+
+	ranked := s.rankHosts(ctx, scores)
+	assert.Equal(t, 3, len(ranked))
+	assert.Equal(t, "name3", ranked[2].name)
+}
+
+func TestUnschedulable(t *testing.T) {
+	ctx := context.Background()
+
+	s := scheduler{}
+
+	nodes := []*common.Node{
+		{Name: "node1"},
+		{Name: "node2"},
+	}
+
+	buf := s.unschedulable(ctx, nodes, nil)
+	assert.Equal(t, "0/2 nodes are available", buf)
+
+	status := []filterStatus{
+		{name: "node1", plugin: "NodeName", error: "invalid name"},
+		{name: "node2", plugin: "NodeName", error: "invalid name"},
+		{name: "node1", plugin: "NodeAffinity", error: "invalid label"},
+		{name: "node2", plugin: "NodeAffinity"},
+	}
+
+	buf = s.unschedulable(ctx, nodes, status)
+	assert.Equal(t, "0/2 nodes are available: NodeName(2), NodeAffinity(1)", buf)
+}
+
+func TestRankCandidates(t *testing.T) {
+	ctx := context.Background()
+
+	s := scheduler{}
+
+	scores := []nodeScore{
+		{name: "name1", score: 1},
+		{name: "name2", score: 2},
+		{name: "name3", score: 2},
+		{name: "name4", score: 2},
+	}
+
+	buf := s.rankCandidates(ctx, scores, "name3")
+	assert.Equal(t, []Candidate{
+		{Name: "name3", Score: 2},
+		{Name: "name2", Score: 2},
+		{Name: "name4", Score: 2},
+		{Name: "name1", Score: 1},
+	}, buf)
+
+	buf = s.rankCandidates(ctx, scores, "name2")
+	assert.Equal(t, "name2", buf[0].Name)
+	assert.Equal(t, "name3", buf[1].Name)
+}
+
+func TestWithCycle(t *testing.T) {
+	ctx := context.Background()
+
+	s := scheduler{}
+
+	task1 := common.Task{Name: "task1"}
+	task2 := common.Task{Name: "task2"}
+
+	c, ok := common.CycleFrom(s.withCycle(ctx, &task1, nil))
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "", c.ID)
+	assert.Equal(t, &task1, c.Task)
+
+	parent := common.NewCycle("id1", &task1, nil)
+	ctx = common.WithCycle(ctx, parent)
+
+	c, _ = common.CycleFrom(s.withCycle(ctx, &task1, nil))
+	assert.Equal(t, parent, c)
+
+	c, _ = common.CycleFrom(s.withCycle(ctx, &task2, nil))
+	assert.NotEqual(t, parent, c)
+	assert.Equal(t, "id1", c.ID)
+	assert.Equal(t, &task2, c.Task)
+}
+
+func TestDeinit(t *testing.T) {
+	ctx := context.Background()
+
+	s := scheduler{
+		cfg: &Config{
+			Config:       cfg,
+			Parallelizer: initParallelizer(&cfg),
+			Plugin:       initPlugin(&cfg),
+		},
+	}
+
+	assert.Equal(t, true, s.acquire())
+
+	done := make(chan struct{})
+
+	go func() {
+		_ = s.Deinit(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Errorf("deinit before release")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	res := s.Run(ctx, &common.Task{}, []*common.Node{{Name: "node1"}})
+	assert.Equal(t, CodeUnavailable, res.Code)
+
+	s.release()
+	<-done
+
+	s = scheduler{
+		cfg: &Config{
+			Config:       cfg,
+			Parallelizer: initParallelizer(&cfg),
+			Plugin:       initPlugin(&cfg),
+		},
+	}
+
+	assert.Equal(t, true, s.acquire())
+
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+
+	_ = s.Deinit(ctx)
+	assert.Equal(t, false, s.acquire())
+}
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+
+	helper := func(c config.Config) error {
+		s := scheduler{
+			cfg: &Config{
+				Config:       c,
+				Parallelizer: initParallelizer(&c),
+				Plugin:       &testPlugin{},
+			},
+		}
+		return s.Init(ctx)
+	}
+
+	c := config.Config{}
+	assert.Equal(t, nil, helper(c))
+
+	c.Spec.Filter.Mode = config.ModeAll
+	assert.Equal(t, nil, helper(c))
+
+	c.Spec.Filter.Mode = "All"
+	assert.NotEqual(t, nil, helper(c))
+
+	c.Spec.Filter.Mode = ""
+	c.Spec.Score.Enabled = []config.Enabled{{Name: "Score", Normalize: config.NormalizeReverse}}
+	assert.Equal(t, nil, helper(c))
+
+	c.Spec.Score.Enabled[0].Normalize = "minmax"
+	assert.NotEqual(t, nil, helper(c))
+
+	c.Spec.Score.Enabled[0].Normalize = ""
+	c.Spec.Fetch.Enabled = []config.Enabled{{Name: "Fetch", Policy: config.PolicyReject}}
+	assert.Equal(t, nil, helper(c))
+
+	c.Spec.Fetch.Enabled[0].Policy = "Reject"
+	assert.NotEqual(t, nil, helper(c))
+}
+
+// testPlugin fails every call on the node named fail, except for the plugins
+// named Score2.
+type testPlugin struct {
+	fail string
+}
+
+func (p *testPlugin) Init(context.Context) error         { return nil }
+func (p *testPlugin) Deinit(context.Context) error       { return nil }
+func (p *testPlugin) Health(context.Context) error       { return nil }
+func (p *testPlugin) List(context.Context) []plugin.Info { return nil }
+
+func (p *testPlugin) RunFetch(_ context.Context, _, host string) (plugin.FetchResult, error) {
+	if host == p.fail {
+		return plugin.FetchResult{}, context.DeadlineExceeded
+	}
+
+	return plugin.FetchResult{}, nil
+}
+
+func (p *testPlugin) RunPreFilter(context.Context, string, *common.Task) (plugin.PreFilterResult, error) {
+	return plugin.PreFilterResult{}, nil
+}
+
+func (p *testPlugin) RunPreScore(context.Context, string, *common.Task, []*common.Node) (plugin.PreScoreResult, error) {
+	return plugin.PreScoreResult{}, nil
+}
+
+func (p *testPlugin) RunNormalizeScore(context.Context, string, *common.Task, []*common.Node, []int64) (plugin.NormalizeScoreResult, error) {
+	return plugin.NormalizeScoreResult{}, nil
+}
+
+func (p *testPlugin) RunFilter(_ context.Context, _ string, _ *common.Task, node *common.Node) (plugin.FilterResult, error) {
+	if node.Name == p.fail {
+		return plugin.FilterResult{}, context.DeadlineExceeded
+	}
+
+	return plugin.FilterResult{}, nil
+}
+
+func (p *testPlugin) RunScore(_ context.Context, name string, _ *common.Task, node *common.Node) (plugin.ScoreResult, error) {
+	if node.Name == p.fail && name != "Score2" {
+		return plugin.ScoreResult{}, context.DeadlineExceeded
+	}
+
+	return plugin.ScoreResult{Score: 1}, nil
+}
+
+func TestPolicy(t *testing.T) {
+	ctx := context.Background()
+	task := &common.Task{Name: "task1"}
+
+	helper := func(fetch, filter, score string) []string {
+		c := config.Config{
+			Spec: config.Spec{
+				Fetch:  config.Plugin{Enabled: []config.Enabled{{Name: "Fetch", Policy: fetch}}},
+				Filter: config.Plugin{Enabled: []config.Enabled{{Name: "Filter", Policy: filter}}},
+				Score: config.Plugin{Enabled: []config.Enabled{
+					{Name: "Score", Policy: score, Weight: 1},
+					{Name: "Score2", Weight: 1},
+				}},
+			},
+		}
+		s := scheduler{
+			cfg: &Config{
+				Config:       c,
+				Parallelizer: initParallelizer(&c),
+				Plugin:       &testPlugin{fail: "node2"},
+			},
+		}
+		nodes := []*common.Node{{Name: "node1", Host: "node1"}, {Name: "node2", Host: "node2"}}
+		nodes, _, _ = s.runFetchPlugins(ctx, nodes)
+		nodes, _, _ = s.runFilterPlugins(ctx, task, nodes)
+		scores, _ := s.runScorePlugins(ctx, task, nodes)
+		var buf []string
+		for _, item := range s.rankHosts(ctx, scores) {
+			buf = append(buf, item.name)
+		}
+		sort.Strings(buf)
+		return buf
+	}
+
+	assert.Equal(t, []string{"node1", "node2"}, helper("", config.PolicySkip, ""))
+	assert.Equal(t, []string{"node1"}, helper(config.PolicyReject, config.PolicySkip, ""))
+	assert.Equal(t, []string{"node1"}, helper("", "", ""))
+	assert.Equal(t, []string{"node1"}, helper("", config.PolicySkip, config.PolicyReject))
+
+	c := config.Config{
+		Spec: config.Spec{
+			Filter: config.Plugin{Enabled: []config.Enabled{{Name: "Filter", Policy: config.PolicySkip}}},
+		},
+	}
+
+	s := scheduler{
+		cfg: &Config{
+			Config:       c,
+			Parallelizer: initParallelizer(&c),
+			Plugin:       &testPlugin{fail: "node2"},
+		},
+	}
+
+	nodes, status, _ := s.runFilterPlugins(ctx, task, []*common.Node{{Name: "node1"}, {Name: "node2"}})
+	assert.Equal(t, 2, len(nodes))
+	assert.Equal(t, "", status[1].error)
+
+	s.cfg.Config.Spec.Filter.Enabled[0].Policy = ""
+
+	nodes, status, _ = s.runFilterPlugins(ctx, task, []*common.Node{{Name: "node1"}, {Name: "node2"}})
+	assert.Equal(t, 1, len(nodes))
+	assert.NotEqual(t, "", status[1].error)
+
+	c = config.Config{
+		Spec: config.Spec{
+			Fetch: config.Plugin{Enabled: []config.Enabled{{Name: "Fetch", Policy: config.PolicyReject}}},
+			Score: config.Plugin{Enabled: []config.Enabled{{Name: "Score", Weight: 1}}},
+		},
+	}
+
+	s = scheduler{
+		cfg: &Config{
+			Config:       c,
+			Parallelizer: initParallelizer(&c),
+			Plugin:       &testPlugin{fail: "node2"},
+		},
+	}
+
+	nodes, status, _ = s.runFetchPlugins(ctx, []*common.Node{{Name: "node1", Host: "node1"}, {Name: "node2", Host: "node2"}})
+	assert.Equal(t, 1, len(nodes))
+	assert.Equal(t, []filterStatus{{name: "node2", plugin: "Fetch", error: context.DeadlineExceeded.Error()}}, status)
+
+	// Nodes rejected by fetch are explained, and counted as unavailable
+	exp := s.Explain(ctx, task, []*common.Node{{Name: "node1", Host: "node1"}, {Name: "node2", Host: "node2"}})
+	assert.Equal(t, "", exp.Error)
+	assert.Equal(t, "node1", exp.Name)
+	assert.Equal(t, 2, len(exp.Nodes))
+	assert.Equal(t, []FilterVerdict{{Plugin: "Fetch", Error: context.DeadlineExceeded.Error()}}, exp.Nodes[1].Filters)
+	assert.Equal(t, false, exp.Nodes[1].Feasible)
+
+	s.cfg.Plugin = &testPlugin{fail: "node1"}
+
+	exp = s.Explain(ctx, task, []*common.Node{{Name: "node1", Host: "node1"}})
+	assert.Equal(t, CodeUnschedulable, exp.Code)
+	assert.Equal(t, "0/1 nodes are available: Fetch(1)", exp.Details)
+}
+
+// testFilterPlugin rejects the node named reject[name] in the filter plugin
+// name, and counts the calls.
+type testFilterPlugin struct {
+	testPlugin
+	reject map[string]string
+	mutex  sync.Mutex
+	calls  int
+}
+
+func (p *testFilterPlugin) RunFilter(_ context.Context, name string, _ *common.Task, node *common.Node) (plugin.FilterResult, error) {
+	p.mutex.Lock()
+	p.calls++
+	p.mutex.Unlock()
+
+	if p.reject[name] == node.Name {
+		return plugin.FilterResult{Error: "rejected by " + name}, nil
+	}
+
+	return plugin.FilterResult{}, nil
+}
+
+func TestFilterMode(t *testing.T) {
+	ctx := context.Background()
+	task := &common.Task{Name: "task1"}
+
+	helper := func(mode string) ([]string, int, error) {
+		c := config.Config{
+			Spec: config.Spec{
+				Filter: config.Plugin{
+					Enabled: []config.Enabled{
+						{Name: "Filter2", Priority: 2},
+						{Name: "Filter1", Priority: 1},
+					},
+					Mode: mode,
+				},
+			},
+		}
+		p := &testFilterPlugin{reject: map[string]string{"Filter1": "node1", "Filter2": "node2"}}
+		s := scheduler{
+			cfg: &Config{
+				Config:       c,
+				Parallelizer: initParallelizer(&c),
+				Plugin:       p,
+			},
+		}
+		nodes := []*common.Node{{Name: "node1"}, {Name: "node2"}, {Name: "node3"}}
+		nodes, _, err := s.runFilterPlugins(ctx, task, nodes)
+		var buf []string
+		for _, item := range nodes {
+			buf = append(buf, item.Name)
+		}
+		return buf, p.calls, err
+	}
+
+	buf, calls, err := helper("")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"node2", "node3"}, buf)
+	assert.Equal(t, 3, calls)
+
+	buf, _, err = helper(config.ModeFirstNonEmpty)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"node2", "node3"}, buf)
+
+	buf, calls, err = helper(config.ModeAll)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"node3"}, buf)
+	assert.Equal(t, 5, calls)
+
+	_, _, err = helper("invalid")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestFilterOrder(t *testing.T) {
+	c := config.Config{
+		Spec: config.Spec{
+			Filter: config.Plugin{Enabled: []config.Enabled{{Name: "Filter"}}},
+		},
+	}
+
+	s := scheduler{
+		cfg: &Config{
+			Config:       c,
+			Parallelizer: initParallelizer(&c),
+			Plugin:       &testFilterPlugin{reject: map[string]string{"Filter": "node1"}},
+		},
+	}
+
+	_ = s.cfg.Parallelizer.Init(context.Background(), parallelizer.DefaultParallelism)
+
+	var nodes []*common.Node
+	for i := 0; i < 1000; i++ {
+		nodes = append(nodes, &common.Node{Name: fmt.Sprintf("node%d", i)})
+	}
+
+	buf, status, err := s.runFilterPlugins(context.Background(), &common.Task{}, nodes)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, append(nodes[:1:1], nodes[2:]...), buf)
+	assert.Equal(t, len(nodes), len(status))
+
+	for i := range status {
+		assert.Equal(t, nodes[i].Name, status[i].name)
+	}
+}
+
+// testSlowPlugin takes delay to score, as a plugin subprocess would.
+type testSlowPlugin struct {
+	testPlugin
+	delay time.Duration
+}
+
+func (p *testSlowPlugin) RunScore(context.Context, string, *common.Task, *common.Node) (plugin.ScoreResult, error) {
+	time.Sleep(p.delay)
+	return plugin.ScoreResult{Score: 1}, nil
+}
+
+func BenchmarkRunScorePlugins(b *testing.B) {
+	ctx := context.Background()
+
+	c := config.Config{
+		Spec: config.Spec{
+			Score: config.Plugin{
+				Enabled: []config.Enabled{
+					{Name: "Score1", Weight: 1},
+					{Name: "Score2", Weight: 1},
+					{Name: "Score3", Weight: 1},
+					{Name: "Score4", Weight: 1},
+				},
+			},
+		},
+	}
+
+	var nodes []*common.Node
+	for i := 0; i < 500; i++ {
+		nodes = append(nodes, &common.Node{Name: fmt.Sprintf("node%d", i)})
+	}
+
+	for _, item := range []int{1, parallelizer.DefaultParallelism} {
+		b.Run(fmt.Sprintf("parallelism=%d", item), func(b *testing.B) {
+			s := scheduler{
+				cfg: &Config{
+					Config:       c,
+					Parallelizer: initParallelizer(&c),
+					Plugin:       &testSlowPlugin{delay: 10 * time.Microsecond},
+				},
+			}
+			_ = s.cfg.Parallelizer.Init(ctx, item)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if buf, _ := s.runScorePlugins(ctx, &common.Task{}, nodes); len(buf) != len(c.Spec.Score.Enabled)*len(nodes) {
+					b.Fatalf("invalid scores")
+				}
+			}
+		})
+	}
+}
+
+// testPrePlugin rejects the task in the PreFilter or PreScore phase of the
+// plugins in reject, and fails the ones in fail.
+type testPrePlugin struct {
+	testPlugin
+	reject map[string]string
+	fail   map[string]bool
+}
+
+func (p *testPrePlugin) RunPreFilter(_ context.Context, name string, _ *common.Task) (plugin.PreFilterResult, error) {
+	if p.fail[name] {
+		return plugin.PreFilterResult{}, context.DeadlineExceeded
+	}
+
+	return plugin.PreFilterResult{Error: p.reject[name]}, nil
+}
+
+func (p *testPrePlugin) RunPreScore(_ context.Context, name string, _ *common.Task, _ []*common.Node) (plugin.PreScoreResult, error) {
+	if p.fail[name] {
+		return plugin.PreScoreResult{}, context.DeadlineExceeded
+	}
+
+	return plugin.PreScoreResult{Error: p.reject[name]}, nil
+}
+
+func TestPrePlugins(t *testing.T) {
+	ctx := context.Background()
+
+	helper := func(p *testPrePlugin, policy string) Result {
+		c := config.Config{
+			Spec: config.Spec{
+				Filter: config.Plugin{Enabled: []config.Enabled{
+					{Name: "Filter2", Priority: 2},
+					{Name: "Filter1", Policy: policy, Priority: 1},
+				}},
+				Score: config.Plugin{Enabled: []config.Enabled{
+					{Name: "Score1", Policy: policy, Weight: 1},
+				}},
+			},
+		}
+		s := scheduler{
+			cfg: &Config{
+				Config:       c,
+				Parallelizer: initParallelizer(&c),
+				Plugin:       p,
+			},
+		}
+		nodes := []*common.Node{{Name: "node1", Host: "node1"}}
+		return s.Run(ctx, &common.Task{Name: "task1"}, nodes)
+	}
+
+	res := helper(&testPrePlugin{}, "")
+	assert.Equal(t, "node1", res.Name)
+
+	res = helper(&testPrePlugin{reject: map[string]string{"Filter1": "invalid selector", "Filter2": "invalid name"}}, "")
+	assert.Equal(t, CodeUnschedulable, res.Code)
+	assert.Equal(t, "PreFilter Filter1: invalid selector", res.Details)
+
+	res = helper(&testPrePlugin{reject: map[string]string{"Score1": "invalid cost"}}, "")
+	assert.Equal(t, "failed to score", res.Error)
+	assert.Equal(t, CodeUnschedulable, res.Code)
+	assert.Equal(t, "PreScore Score1: invalid cost", res.Details)
+
+	res = helper(&testPrePlugin{fail: map[string]bool{"Filter1": true}}, "")
+	assert.Equal(t, CodeFilterFailed, res.Code)
+
+	res = helper(&testPrePlugin{fail: map[string]bool{"Filter1": true, "Score1": true}}, config.PolicySkip)
+	assert.Equal(t, "node1", res.Name)
+
+	res = helper(&testPrePlugin{fail: map[string]bool{"Score1": true}}, config.PolicyReject)
+	assert.Equal(t, CodeScoreFailed, res.Code)
+}
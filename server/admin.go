@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pipego/scheduler/config"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+const (
+	redacted = "REDACTED"
+)
+
+// admin serves AdminProto, which reports on the running server.
+type admin struct {
+	server *server
+	pb.UnimplementedAdminProtoServer
+}
+
+// reloadStatus is the last failed reload, which is cleared once a reload
+// succeeds.
+type reloadStatus struct {
+	revision string
+	error    string
+	time     time.Time
+}
+
+// Rollback records a failed reload of revision, and keeps serving the current
+// config.
+func (s *server) Rollback(_ context.Context, revision string, err error) {
+	s.mutex.Lock()
+	cfg := s.cfg
+	s.reload = reloadStatus{revision: revision, error: err.Error(), time: time.Now()}
+	s.mutex.Unlock()
+
+	cfg.Logger.Error("server", zap.String("reload", "rolled back"), zap.String("revision", cfg.Revision),
+		zap.String("failedRevision", revision), zap.Error(err))
+}
+
+func (a *admin) GetReloadStatus(_ context.Context, _ *pb.ReloadStatusRequest) (*pb.ReloadStatusReply, error) {
+	a.server.mutex.RLock()
+	defer a.server.mutex.RUnlock()
+
+	reply := &pb.ReloadStatusReply{
+		Revision:       a.server.cfg.Revision,
+		FailedRevision: a.server.reload.revision,
+		Error:          a.server.reload.error,
+	}
+
+	if !a.server.reload.time.IsZero() {
+		reply.FailedTime = a.server.reload.time.Unix()
+	}
+
+	return reply, nil
+}
+
+func (a *admin) ListPlugins(ctx context.Context, _ *pb.ListPluginsRequest) (*pb.ListPluginsReply, error) {
+	cfg := a.server.config()
+	reply := &pb.ListPluginsReply{}
+
+	if cfg.Plugin == nil {
+		return reply, nil
+	}
+
+	for _, item := range cfg.Plugin.List(ctx) {
+		reply.Plugins = append(reply.Plugins, &pb.PluginInfo{
+			Name:     item.Name,
+			Type:     item.Type,
+			Path:     item.Path,
+			Enabled:  item.Enabled,
+			Priority: item.Priority,
+			Weight:   item.Weight,
+			Pid:      item.Pid,
+		})
+	}
+
+	return reply, nil
+}
+
+func (a *admin) GetConfig(_ context.Context, _ *pb.ConfigRequest) (*pb.ConfigReply, error) {
+	cfg := a.server.config()
+
+	buf, err := yaml.Marshal(redactHelper(cfg.Config))
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to marshal config").Error())
+	}
+
+	return &pb.ConfigReply{Revision: cfg.Revision, Config: string(buf)}, nil
+}
+
+func (a *admin) GetVersion(_ context.Context, _ *pb.VersionRequest) (*pb.VersionReply, error) {
+	return &pb.VersionReply{Version: config.Version, Build: config.Build}, nil
+}
+
+// redactHelper returns a copy of cfg without the auth token values.
+func redactHelper(cfg config.Config) config.Config {
+	tokens := make([]config.Token, len(cfg.Spec.Server.Auth.Tokens))
+
+	for i, item := range cfg.Spec.Server.Auth.Tokens {
+		tokens[i] = config.Token{Names: item.Names, Value: redacted}
+	}
+
+	cfg.Spec.Server.Auth.Tokens = tokens
+
+	return cfg
+}
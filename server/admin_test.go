@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/health"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pipego/scheduler/config"
+	"github.com/pipego/scheduler/parallelizer"
+	"github.com/pipego/scheduler/plugin"
+	"github.com/pipego/scheduler/scheduler"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+func TestGetReloadStatus(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	s.cfg.Revision = "revision1"
+	s.health = health.NewServer()
+	a := &admin{server: s}
+
+	r, err := a.GetReloadStatus(ctx, &pb.ReloadStatusRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "revision1", r.GetRevision())
+	assert.Equal(t, "", r.GetFailedRevision())
+	assert.Equal(t, int64(0), r.GetFailedTime())
+
+	err = s.Reload(ctx, &Config{
+		Revision:  "revision2",
+		Logger:    &testLogger{},
+		Scheduler: &testScheduler{health: errors.New("invalid filter NodeName")},
+	})
+	assert.NotEqual(t, nil, err)
+
+	r, _ = a.GetReloadStatus(ctx, &pb.ReloadStatusRequest{})
+	assert.Equal(t, "revision1", r.GetRevision())
+	assert.Equal(t, "revision2", r.GetFailedRevision())
+	assert.Equal(t, "invalid filter NodeName", r.GetError())
+	assert.NotEqual(t, int64(0), r.GetFailedTime())
+
+	err = s.Reload(ctx, &Config{Revision: "revision3", Logger: &testLogger{}, Scheduler: &testScheduler{}})
+	assert.Equal(t, nil, err)
+
+	r, _ = a.GetReloadStatus(ctx, &pb.ReloadStatusRequest{})
+	assert.Equal(t, "revision3", r.GetRevision())
+	assert.Equal(t, "", r.GetFailedRevision())
+	assert.Equal(t, "", r.GetError())
+}
+
+type testPlugin struct {
+	plugin.Plugin
+}
+
+func (p *testPlugin) List(context.Context) []plugin.Info {
+	return []plugin.Info{{Name: "NodeName", Type: plugin.TypeFilter, Enabled: true, Priority: 1, Pid: 100}}
+}
+
+func TestListPlugins(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	a := &admin{server: s}
+
+	r, err := a.ListPlugins(ctx, &pb.ListPluginsRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(r.GetPlugins()))
+
+	s.cfg.Plugin = &testPlugin{}
+
+	r, err = a.ListPlugins(ctx, &pb.ListPluginsRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(r.GetPlugins()))
+	assert.Equal(t, "NodeName", r.GetPlugins()[0].GetName())
+	assert.Equal(t, plugin.TypeFilter, r.GetPlugins()[0].GetType())
+	assert.Equal(t, true, r.GetPlugins()[0].GetEnabled())
+	assert.Equal(t, int64(100), r.GetPlugins()[0].GetPid())
+}
+
+func TestGetConfig(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	s.cfg.Revision = "revision1"
+	s.cfg.Config.Spec.Server.Auth.Tokens = []config.Token{{Names: []string{"name1"}, Value: "token1"}}
+	s.cfg.Config.Spec.Server.Shutdown.Timeout = 30 * time.Second
+	a := &admin{server: s}
+
+	r, err := a.GetConfig(ctx, &pb.ConfigRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "revision1", r.GetRevision())
+
+	cfg := config.New()
+	err = yaml.Unmarshal([]byte(r.GetConfig()), cfg)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, redacted, cfg.Spec.Server.Auth.Tokens[0].Value)
+	assert.Equal(t, []string{"name1"}, cfg.Spec.Server.Auth.Tokens[0].Names)
+	assert.Equal(t, 30*time.Second, cfg.Spec.Server.Shutdown.Timeout)
+
+	assert.Equal(t, "token1", s.cfg.Config.Spec.Server.Auth.Tokens[0].Value)
+}
+
+func TestGetVersion(t *testing.T) {
+	config.Version = "v1.0.0"
+	config.Build = "build1"
+
+	a := &admin{server: testServer()}
+
+	r, err := a.GetVersion(context.Background(), &pb.VersionRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "v1.0.0", r.GetVersion())
+	assert.Equal(t, "build1", r.GetBuild())
+}
+
+func TestRollbackPlugins(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	s.health = health.NewServer()
+
+	c := config.Config{
+		Spec: config.Spec{
+			Fetch: config.Plugin{
+				Enabled: []config.Enabled{{Name: "LocalHost", Path: "../fetch-localhost"}},
+			},
+			Filter: config.Plugin{
+				Enabled: []config.Enabled{{Name: "NodeName", Path: "../filter-nodename"}},
+			},
+			Score: config.Plugin{
+				Enabled: []config.Enabled{{Name: "NodeResourcesFit", Path: "../score-invalid"}},
+			},
+		},
+	}
+
+	pl := plugin.New(ctx, &plugin.Config{Config: c})
+	pa := parallelizer.New(ctx, &parallelizer.Config{Config: c})
+	sched := scheduler.New(ctx, &scheduler.Config{Config: c, Parallelizer: pa, Plugin: pl})
+
+	err := s.Reload(ctx, &Config{Revision: "revision2", Config: c, Logger: &testLogger{}, Plugin: pl, Scheduler: sched})
+	assert.NotEqual(t, nil, err)
+
+	buf := pl.List(ctx)
+	assert.NotEqual(t, 0, len(buf))
+
+	for _, item := range buf {
+		assert.Equal(t, int64(0), item.Pid)
+	}
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pipego/scheduler/config"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+const (
+	RetryAfterKey = "retry-after"
+
+	// retryAfter is the hint for requests rejected by the concurrency limit.
+	retryAfter = time.Second
+
+	rateLimitAny = "*"
+)
+
+// admitMethods are the methods which schedule, and so are admitted. Polling
+// results is not, so that it never uses up the rate of the caller. Submit is
+// admitted by the handler, since its job is queued beyond the call.
+var admitMethods = map[string]bool{
+	pb.ServerProto_SendServer_FullMethodName:      true,
+	pb.ServerProto_SendServerBatch_FullMethodName: true,
+	pb.ServerProto_Explain_FullMethodName:         true,
+}
+
+// admitter bounds the schedules running at once, the schedules waiting for
+// them, and the rate of schedules of each metadata.name.
+type admitter struct {
+	slots    chan struct{}
+	depth    int64
+	waiting  int64
+	limiters map[string]*rate.Limiter
+}
+
+func newAdmitter(cfg config.Admission) *admitter {
+	a := &admitter{
+		depth:    cfg.QueueDepth,
+		limiters: make(map[string]*rate.Limiter),
+	}
+
+	if cfg.MaxConcurrent > 0 {
+		a.slots = make(chan struct{}, cfg.MaxConcurrent)
+	}
+
+	for _, item := range cfg.RateLimits {
+		if item.Rate <= 0 {
+			continue
+		}
+		burst := int(item.Burst)
+		if burst <= 0 {
+			burst = 1
+		}
+		a.limiters[item.Name] = rate.NewLimiter(rate.Limit(item.Rate), burst)
+	}
+
+	return a
+}
+
+// admit returns once a slot is free, or fails with ResourceExhausted if the
+// queue is full or name is over its rate. release frees the slot.
+func (a *admitter) admit(ctx context.Context, name string) (release func(), err error) {
+	wait, err := a.enqueue(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return wait(ctx)
+}
+
+// enqueue takes a free slot, or else a place in the queue, and returns wait to
+// get the slot, e.g. once a submitted job runs. It fails with ResourceExhausted
+// if the queue is full or name is over its rate.
+func (a *admitter) enqueue(ctx context.Context, name string) (wait func(context.Context) (func(), error), err error) {
+	l, ok := a.limiters[name]
+	if !ok {
+		l, ok = a.limiters[rateLimitAny]
+	}
+
+	// Give the token back if the request is not admitted after all
+	cancel := func() {}
+
+	if ok {
+		r := l.Reserve()
+		if d := r.Delay(); d > 0 {
+			r.Cancel()
+			return nil, retryHelper(ctx, d, "metadata.name: %q is over its rate limit", name)
+		}
+		cancel = r.Cancel
+	}
+
+	if a.slots == nil {
+		return func(context.Context) (func(), error) { return func() {}, nil }, nil
+	}
+
+	select {
+	case a.slots <- struct{}{}:
+		return func(context.Context) (func(), error) { return a.release, nil }, nil
+	default:
+	}
+
+	if atomic.AddInt64(&a.waiting, 1) > a.depth {
+		atomic.AddInt64(&a.waiting, -1)
+		cancel()
+		return nil, retryHelper(ctx, retryAfter, "too many requests")
+	}
+
+	return func(ctx context.Context) (func(), error) {
+		defer atomic.AddInt64(&a.waiting, -1)
+		select {
+		case a.slots <- struct{}{}:
+			return a.release, nil
+		case <-ctx.Done():
+			cancel()
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}, nil
+}
+
+func (a *admitter) release() {
+	<-a.slots
+}
+
+func (s *server) unaryAdmit(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	a := s.admitter()
+	if a == nil || !admitMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	var name string
+
+	if r, ok := req.(metadataGetter); ok {
+		name = r.GetMetadata().GetName()
+	}
+
+	release, err := a.admit(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	return handler(ctx, req)
+}
+
+// admitter returns the admitter of the current config, which is swapped by
+// Reload.
+func (s *server) admitter() *admitter {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.admit
+}
+
+// retryHelper returns ResourceExhausted with a hint of when to retry, both as
+// RetryInfo and in the retry-after header (seconds).
+func retryHelper(ctx context.Context, d time.Duration, format string, args ...interface{}) error {
+	secs := int64((d + time.Second - 1) / time.Second)
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterKey, strconv.FormatInt(secs, 10)))
+
+	st := status.Newf(codes.ResourceExhausted, format, args...)
+	if buf, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(d)}); err == nil {
+		st = buf
+	}
+
+	return st.Err()
+}
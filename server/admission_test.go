@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/status"
+
+	"github.com/pipego/scheduler/config"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+func TestAdmit(t *testing.T) {
+	ctx := context.Background()
+
+	a := newAdmitter(config.Admission{MaxConcurrent: 1, QueueDepth: 1})
+
+	release, err := a.admit(ctx, "name1")
+	assert.Equal(t, nil, err)
+
+	done := make(chan error)
+
+	go func() {
+		r, err := a.admit(ctx, "name1")
+		if err == nil {
+			r()
+		}
+		done <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = a.admit(ctx, "name1")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	details := status.Convert(err).Details()
+	assert.Equal(t, 1, len(details))
+	assert.Equal(t, retryAfter, details[0].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+
+	release()
+	assert.Equal(t, nil, <-done)
+
+	c, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+
+	release, _ = a.admit(ctx, "name1")
+	_, err = a.admit(c, "name1")
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	release()
+}
+
+func TestAdmitRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	a := newAdmitter(config.Admission{
+		RateLimits: []config.RateLimit{
+			{Name: "name1", Rate: 1, Burst: 1},
+			{Name: rateLimitAny, Rate: 1, Burst: 2},
+		},
+	})
+
+	_, err := a.admit(ctx, "name1")
+	assert.Equal(t, nil, err)
+
+	_, err = a.admit(ctx, "name1")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = a.admit(ctx, "name2")
+	assert.Equal(t, nil, err)
+
+	_, err = a.admit(ctx, "name3")
+	assert.Equal(t, nil, err)
+
+	_, err = a.admit(ctx, "name2")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	a = newAdmitter(config.Admission{})
+
+	for i := 0; i < 10; i++ {
+		_, err = a.admit(ctx, "name1")
+		assert.Equal(t, nil, err)
+	}
+}
+
+func TestHandleScheduleAdmission(t *testing.T) {
+	s := testServer()
+	s.admit = newAdmitter(config.Admission{
+		RateLimits: []config.RateLimit{{Name: "scheduler", Rate: 0.5, Burst: 1}},
+	})
+
+	body := `{
+  "apiVersion": "v1",
+  "kind": "scheduler",
+  "metadata": {"name": "scheduler"},
+  "spec": {"task": {"name": "node1"}, "nodes": [{"name": "node1"}]}
+}`
+
+	h := s.httpHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/schedule", strings.NewReader(body)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/schedule", strings.NewReader(body)))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+}
+
+func TestReloadAdmission(t *testing.T) {
+	ctx := context.Background()
+
+	helper := func(c config.Admission) *Config {
+		cfg := &Config{Logger: &testLogger{}, Scheduler: &testScheduler{}}
+		cfg.Config.Spec.Server.Admission = c
+		return cfg
+	}
+
+	s := testServer()
+	s.cfg = helper(config.Admission{MaxConcurrent: 1})
+	s.admit = newAdmitter(s.cfg.Config.Spec.Server.Admission)
+	s.health = health.NewServer()
+
+	release, err := s.admit.admit(ctx, "name1")
+	assert.Equal(t, nil, err)
+
+	// The slots in use are kept across a reload of the same admission
+	err = s.Reload(ctx, helper(config.Admission{MaxConcurrent: 1}))
+	assert.Equal(t, nil, err)
+
+	_, err = s.admit.admit(ctx, "name1")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	err = s.Reload(ctx, helper(config.Admission{MaxConcurrent: 2}))
+	assert.Equal(t, nil, err)
+
+	r, err := s.admit.admit(ctx, "name1")
+	assert.Equal(t, nil, err)
+
+	r()
+	release()
+}
+
+func TestUnaryAdmit(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	s.admit = newAdmitter(config.Admission{
+		RateLimits: []config.RateLimit{{Name: "name1", Rate: 0.5, Burst: 1}},
+	})
+
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	helper := func(method string) error {
+		req := &pb.ResultRequest{Metadata: &pb.Metadata{Name: "name1"}}
+		_, err := s.unaryAdmit(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, nil, helper(pb.ServerProto_GetResult_FullMethodName))
+	}
+
+	assert.Equal(t, nil, helper(pb.ServerProto_SendServer_FullMethodName))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(helper(pb.ServerProto_SendServer_FullMethodName)))
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pipego/scheduler/config"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+const (
+	AuthKey   = "authorization"
+	ApiKeyKey = "x-api-key"
+
+	authScheme = "bearer "
+)
+
+type metadataGetter interface {
+	GetMetadata() *pb.Metadata
+}
+
+// authStream authorizes every message received on the stream.
+type authStream struct {
+	grpc.ServerStream
+	server *server
+	token  *config.Token
+}
+
+func (a *authStream) RecvMsg(m interface{}) error {
+	if err := a.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return a.server.authorize(a.token, m)
+}
+
+func (s *server) unaryAuth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	token, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(token, req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (s *server) streamAuth(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	token, err := s.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authStream{ServerStream: ss, server: s, token: token})
+}
+
+// authenticate matches the bearer token or the pre-shared key of the caller
+// against the config. It returns nil if auth is disabled or the method is
+// exempt, e.g. health checking.
+func (s *server) authenticate(ctx context.Context, method string) (*config.Token, error) {
+	var key string
+
+	tokens := s.config().Config.Spec.Server.Auth.Tokens
+	if len(tokens) == 0 || strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+		return nil, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if buf := md.Get(AuthKey); len(buf) != 0 && strings.HasPrefix(strings.ToLower(buf[0]), authScheme) {
+		key = strings.TrimSpace(buf[0][len(authScheme):])
+	} else if buf := md.Get(ApiKeyKey); len(buf) != 0 {
+		key = buf[0]
+	}
+
+	if key == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+
+	for i := range tokens {
+		if subtle.ConstantTimeCompare([]byte(tokens[i].Value), []byte(key)) == 1 {
+			return &tokens[i], nil
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "invalid token")
+}
+
+// authorize checks metadata.name of the request against the names allowed
+// for token.
+func (s *server) authorize(token *config.Token, req interface{}) error {
+	if token == nil || len(token.Names) == 0 {
+		return nil
+	}
+
+	// Tokens restricted to names are not allowed to use the admin service
+	r, ok := req.(metadataGetter)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "token is restricted to metadata.name")
+	}
+
+	name := r.GetMetadata().GetName()
+
+	for _, item := range token.Names {
+		if item == name {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.PermissionDenied, "metadata.name: %q is not allowed", name)
+}
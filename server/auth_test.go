@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pipego/scheduler/config"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+func TestAuthenticate(t *testing.T) {
+	method := "/" + pb.ServerProto_ServiceDesc.ServiceName + "/SendServer"
+	s := testServer()
+
+	token, err := s.authenticate(context.Background(), method)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, (*config.Token)(nil), token)
+
+	s.cfg.Config.Spec.Server.Auth.Tokens = []config.Token{
+		{Value: "token1"},
+		{Value: "token2", Names: []string{"name2"}},
+	}
+
+	_, err = s.authenticate(context.Background(), method)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = s.authenticate(context.Background(), "/"+healthpb.Health_ServiceDesc.ServiceName+"/Check")
+	assert.Equal(t, nil, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthKey, "Bearer invalid"))
+	_, err = s.authenticate(ctx, method)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthKey, "Bearer token1"))
+	token, err = s.authenticate(ctx, method)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "token1", token.Value)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(ApiKeyKey, "token2"))
+	token, err = s.authenticate(ctx, method)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "token2", token.Value)
+}
+
+func TestAuthorize(t *testing.T) {
+	s := testServer()
+	req := &pb.ServerRequest{Metadata: &pb.Metadata{Name: "name1"}}
+
+	err := s.authorize(nil, req)
+	assert.Equal(t, nil, err)
+
+	err = s.authorize(&config.Token{Value: "token1"}, req)
+	assert.Equal(t, nil, err)
+
+	err = s.authorize(&config.Token{Value: "token2", Names: []string{"name2"}}, req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	err = s.authorize(&config.Token{Value: "token2", Names: []string{"name1", "name2"}}, req)
+	assert.Equal(t, nil, err)
+
+	err = s.authorize(&config.Token{Value: "token1"}, &pb.ReloadStatusRequest{})
+	assert.Equal(t, nil, err)
+
+	err = s.authorize(&config.Token{Value: "token2", Names: []string{"name1"}}, &pb.ReloadStatusRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestUnaryAuth(t *testing.T) {
+	s := testServer()
+	s.cfg.Config.Spec.Server.Auth.Tokens = []config.Token{
+		{Value: "token1", Names: []string{"name1"}},
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + pb.ServerProto_ServiceDesc.ServiceName + "/SendServer"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.ServerReply{Name: "node1"}, nil
+	}
+
+	_, err := s.unaryAuth(context.Background(), &pb.ServerRequest{}, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthKey, "Bearer token1"))
+
+	_, err = s.unaryAuth(ctx, &pb.ServerRequest{Metadata: &pb.Metadata{Name: "name2"}}, info, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	r, err := s.unaryAuth(ctx, &pb.ServerRequest{Metadata: &pb.Metadata{Name: "name1"}}, info, handler)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node1", r.(*pb.ServerReply).GetName())
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+const (
+	healthInterval = 10 * time.Second
+)
+
+// setServing reports the status of both the whole server and ServerProto.
+func (s *server) setServing(serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+
+	s.health.SetServingStatus("", status)
+	s.health.SetServingStatus(pb.ServerProto_ServiceDesc.ServiceName, status)
+}
+
+// watchHealth keeps the status in line with the plugin subprocesses until the
+// server is deinited.
+func (s *server) watchHealth(ctx context.Context, done <-chan struct{}) {
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.setServing(s.config().Scheduler.Health(ctx) == nil)
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pipego/scheduler/config"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+var (
+	httpCodes = map[codes.Code]int{
+		codes.OK:                 http.StatusOK,
+		codes.Canceled:           http.StatusRequestTimeout,
+		codes.InvalidArgument:    http.StatusBadRequest,
+		codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+		codes.NotFound:           http.StatusNotFound,
+		codes.PermissionDenied:   http.StatusForbidden,
+		codes.ResourceExhausted:  http.StatusTooManyRequests,
+		codes.FailedPrecondition: http.StatusPreconditionFailed,
+		codes.Unimplemented:      http.StatusNotImplemented,
+		codes.Unavailable:        http.StatusServiceUnavailable,
+		codes.Unauthenticated:    http.StatusUnauthorized,
+	}
+
+	// httpHeaders are passed to the interceptors as gRPC metadata.
+	httpHeaders = []string{AuthKey, ApiKeyKey, IdempotencyKey, RequestIDKey}
+)
+
+// httpHandler serves the scheduling API as JSON, in the same shape as
+// ServerRequest and ServerReply.
+func (s *server) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/schedule", s.handleSchedule)
+	mux.HandleFunc("/healthz", s.handleHealth)
+	mux.HandleFunc("/version", s.handleVersion)
+
+	return mux
+}
+
+func (s *server) handleSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	buf, err := io.ReadAll(http.MaxBytesReader(w, r.Body, math.MaxInt32))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := &pb.ServerRequest{}
+
+	if err := protojson.Unmarshal(buf, req); err != nil {
+		s.writeHttp(w, http.StatusBadRequest, &pb.ServerReply{
+			Error:   "invalid spec",
+			Code:    pb.ErrorCode_ERROR_CODE_INVALID_SPEC,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	md := metadata.MD{}
+
+	for _, item := range httpHeaders {
+		if val := r.Header.Get(item); val != "" {
+			md.Set(item, val)
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+
+	reply, err := s.invoke(ctx, pb.ServerProto_SendServer_FullMethodName, req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.SendServer(ctx, req.(*pb.ServerRequest))
+	})
+
+	if err != nil {
+		st := status.Convert(err)
+		code, ok := httpCodes[st.Code()]
+		if !ok {
+			code = http.StatusInternalServerError
+		}
+		reply := &pb.ServerReply{Error: st.Message()}
+		for _, item := range st.Details() {
+			switch d := item.(type) {
+			case *pb.ServerReply:
+				reply = d
+			case *errdetails.RetryInfo:
+				w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(d.GetRetryDelay().AsDuration().Seconds())), 10))
+			}
+		}
+		s.writeHttp(w, code, reply)
+		return
+	}
+
+	s.writeHttp(w, http.StatusOK, reply.(proto.Message))
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	res, err := s.health.Check(r.Context(), &healthpb.HealthCheckRequest{})
+	if err != nil || res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		http.Error(w, healthpb.HealthCheckResponse_NOT_SERVING.String(), http.StatusServiceUnavailable)
+		return
+	}
+
+	_, _ = io.WriteString(w, res.GetStatus().String())
+}
+
+func (s *server) handleVersion(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"build":   config.Build,
+		"version": config.Version,
+	})
+}
+
+func (s *server) writeHttp(w http.ResponseWriter, code int, m proto.Message) {
+	buf, err := protojson.Marshal(m)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(buf)
+}
+
+// invoke runs handler behind the same unary interceptors as the gRPC server.
+func (s *server) invoke(ctx context.Context, method string, req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	info := &grpc.UnaryServerInfo{
+		Server:     s,
+		FullMethod: method,
+	}
+
+	interceptors := s.unaryInterceptors()
+	chain := handler
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		item, next := interceptors[i], chain
+		chain = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return item(ctx, req, info, next)
+		}
+	}
+
+	return chain(ctx, req)
+}
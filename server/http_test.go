@@ -0,0 +1,107 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/health"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/pipego/scheduler/config"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+func TestHandleSchedule(t *testing.T) {
+	helper := func(h http.Handler, method, body string, header map[string]string) (int, *pb.ServerReply) {
+		req := httptest.NewRequest(method, "/v1/schedule", strings.NewReader(body))
+		for key, val := range header {
+			req.Header.Set(key, val)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		reply := &pb.ServerReply{}
+		_ = protojson.Unmarshal(rec.Body.Bytes(), reply)
+		return rec.Code, reply
+	}
+
+	s := testServer()
+	h := s.httpHandler()
+
+	code, _ := helper(h, http.MethodGet, "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, reply := helper(h, http.MethodPost, "{", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_SPEC, reply.GetCode())
+
+	code, reply = helper(h, http.MethodPost, `{"kind": "invalid"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_KIND, reply.GetCode())
+	assert.Equal(t, "invalid kind", reply.GetError())
+
+	body := `{
+  "apiVersion": "v1",
+  "kind": "scheduler",
+  "metadata": {"name": "scheduler"},
+  "spec": {
+    "task": {"name": "node1", "requestedResource": {"milliCPU": 256}},
+    "nodes": [{"name": "node1", "allocatableResource": {"milliCPU": 1024}}]
+  }
+}`
+
+	code, reply = helper(h, http.MethodPost, body, nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "node1", reply.GetName())
+
+	code, reply = helper(h, http.MethodPost, strings.Replace(body, `"name": "node1", "req`, `"name": "node2", "req`, 1), nil)
+	assert.Equal(t, http.StatusPreconditionFailed, code)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_UNSCHEDULABLE, reply.GetCode())
+
+	s.cfg.Config.Spec.Server.Auth.Tokens = []config.Token{{Value: "token1"}}
+
+	code, _ = helper(h, http.MethodPost, body, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, reply = helper(h, http.MethodPost, body, map[string]string{"Authorization": "Bearer token1"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "node1", reply.GetName())
+}
+
+func TestHandleHealth(t *testing.T) {
+	s := testServer()
+	s.health = health.NewServer()
+	h := s.httpHandler()
+
+	s.setServing(false)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	s.setServing(true)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandleVersion(t *testing.T) {
+	config.Build = "build1"
+	config.Version = "version1"
+
+	s := testServer()
+	h := s.httpHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	buf := map[string]string{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &buf)
+	assert.Equal(t, "build1", buf["build"])
+	assert.Equal(t, "version1", buf["version"])
+}
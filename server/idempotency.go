@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+const (
+	IdempotencyKey = "idempotency-key"
+)
+
+// idempotency caches the decisions by key for a window, so that a retried
+// request gets the same node without running the plugins again. Requests with
+// a key in flight wait for its decision. A key is only reused by the same
+// request, which is checked by the hash of its body.
+type idempotency struct {
+	mutex     sync.Mutex
+	decisions map[string]*decision
+}
+
+// decision is in flight until done is closed. Failed decisions are dropped, so
+// that they are retried.
+type decision struct {
+	hash   string
+	done   chan struct{}
+	reply  *pb.ServerReply
+	expire time.Time
+}
+
+func newIdempotency() *idempotency {
+	return &idempotency{
+		decisions: make(map[string]*decision),
+	}
+}
+
+func (i *idempotency) do(ctx context.Context, key, hash string, window time.Duration,
+	fn func() (*pb.ServerReply, error)) (*pb.ServerReply, error) {
+	if window <= 0 || key == "" {
+		return fn()
+	}
+
+	for {
+		i.mutex.Lock()
+		i.sweep(time.Now())
+
+		d, ok := i.decisions[key]
+		if !ok {
+			d = &decision{hash: hash, done: make(chan struct{})}
+			i.decisions[key] = d
+			i.mutex.Unlock()
+			return i.decide(key, d, window, fn)
+		}
+
+		i.mutex.Unlock()
+
+		if d.hash != hash {
+			return nil, status.Error(codes.InvalidArgument, "idempotency key reused with a different request")
+		}
+
+		select {
+		case <-d.done:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+
+		if d.reply != nil {
+			return d.reply, nil
+		}
+	}
+}
+
+func (i *idempotency) decide(key string, d *decision, window time.Duration,
+	fn func() (*pb.ServerReply, error)) (*pb.ServerReply, error) {
+	reply, err := fn()
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if err != nil {
+		delete(i.decisions, key)
+	} else {
+		d.reply = reply
+		d.expire = time.Now().Add(window)
+	}
+
+	close(d.done)
+
+	return reply, err
+}
+
+func (i *idempotency) sweep(now time.Time) {
+	for key, val := range i.decisions {
+		if val.reply != nil && now.After(val.expire) {
+			delete(i.decisions, key)
+		}
+	}
+}
+
+// idempotencyKey returns the idempotency key of the caller, or task.name by
+// default, scoped to metadata.name. It is empty if neither is set, since tasks
+// without a name are not the same task.
+func idempotencyKey(ctx context.Context, in *pb.ServerRequest) string {
+	key := in.GetSpec().GetTask().GetName()
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if buf := md.Get(IdempotencyKey); len(buf) != 0 && buf[0] != "" {
+			key = buf[0]
+		}
+	}
+
+	if key == "" {
+		return ""
+	}
+
+	return in.GetMetadata().GetName() + "/" + key
+}
+
+// idempotencyHash returns the hash of spec and topN of in, which are encoded
+// deterministically.
+func idempotencyHash(in *pb.ServerRequest) string {
+	buf, _ := proto.MarshalOptions{Deterministic: true}.Marshal(in.GetSpec())
+	buf = binary.BigEndian.AppendUint64(buf, uint64(in.GetTopN()))
+
+	sum := sha256.Sum256(buf)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+func TestIdempotency(t *testing.T) {
+	ctx := context.Background()
+	i := newIdempotency()
+
+	var calls int64
+
+	helper := func(name string, err error) func() (*pb.ServerReply, error) {
+		return func() (*pb.ServerReply, error) {
+			atomic.AddInt64(&calls, 1)
+			time.Sleep(50 * time.Millisecond)
+			return &pb.ServerReply{Name: name}, err
+		}
+	}
+
+	r, err := i.do(ctx, "key1", "hash1", 0, helper("node1", nil))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node1", r.GetName())
+
+	r, err = i.do(ctx, "key1", "hash1", 0, helper("node2", nil))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node2", r.GetName())
+	assert.Equal(t, int64(2), calls)
+
+	calls = 0
+
+	var wg sync.WaitGroup
+
+	for n := 0; n < 3; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, err := i.do(ctx, "key2", "hash1", 100*time.Millisecond, helper("node1", nil))
+			assert.Equal(t, nil, err)
+			assert.Equal(t, "node1", r.GetName())
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int64(1), calls)
+
+	r, err = i.do(ctx, "key2", "hash1", 100*time.Millisecond, helper("node2", nil))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node1", r.GetName())
+	assert.Equal(t, int64(1), calls)
+
+	time.Sleep(200 * time.Millisecond)
+
+	r, err = i.do(ctx, "key2", "hash1", 100*time.Millisecond, helper("node2", nil))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node2", r.GetName())
+	assert.Equal(t, int64(2), calls)
+
+	_, err = i.do(ctx, "key3", "hash1", 100*time.Millisecond, helper("", errors.New("failed to schedule")))
+	assert.NotEqual(t, nil, err)
+
+	r, err = i.do(ctx, "key3", "hash1", 100*time.Millisecond, helper("node1", nil))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node1", r.GetName())
+
+	_, err = i.do(ctx, "key3", "hash2", 100*time.Millisecond, helper("node2", nil))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestIdempotencyHash(t *testing.T) {
+	in := &pb.ServerRequest{
+		Spec: &pb.Spec{
+			Task:  &pb.Task{Name: "task1"},
+			Nodes: []*pb.Node{{Name: "node1"}},
+		},
+	}
+
+	hash := idempotencyHash(in)
+	assert.Equal(t, hash, idempotencyHash(proto.Clone(in).(*pb.ServerRequest)))
+
+	in.TopN = 1
+	assert.NotEqual(t, hash, idempotencyHash(in))
+
+	in.TopN = 0
+	in.Spec.Nodes[0].AllocatableResource = &pb.AllocatableResource{MilliCPU: 1000}
+	assert.NotEqual(t, hash, idempotencyHash(in))
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	in := &pb.ServerRequest{
+		Metadata: &pb.Metadata{Name: "name1"},
+		Spec:     &pb.Spec{Task: &pb.Task{Name: "task1"}},
+	}
+
+	key := idempotencyKey(context.Background(), in)
+	assert.Equal(t, "name1/task1", key)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKey, "key1"))
+
+	key = idempotencyKey(ctx, in)
+	assert.Equal(t, "name1/key1", key)
+
+	in.Spec.Task.Name = ""
+
+	key = idempotencyKey(context.Background(), in)
+	assert.Equal(t, "", key)
+}
+
+func TestSendServerIdempotent(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	s.cfg.Config.Spec.Server.Idempotency.Window = time.Minute
+
+	helper := func(task, node string) (*pb.ServerReply, error) {
+		return s.SendServer(ctx, &pb.ServerRequest{
+			ApiVersion: ApiVersion,
+			Kind:       Kind,
+			Metadata:   &pb.Metadata{Name: "name1"},
+			Spec: &pb.Spec{
+				Task:  &pb.Task{Name: task},
+				Nodes: []*pb.Node{{Name: node}},
+			},
+		})
+	}
+
+	r, err := helper("node1", "node1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node1", r.GetName())
+
+	// The decision of the first request is reused
+	r, err = helper("node1", "node1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node1", r.GetName())
+
+	// The key is not reused by a request with other nodes
+	_, err = helper("node1", "node2")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Tasks without a name are not deduplicated
+	r, err = helper("", "node1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node1", r.GetName())
+
+	r, err = helper("", "node2")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node2", r.GetName())
+}
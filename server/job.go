@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pipego/scheduler/common"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+const (
+	jobIDLen = 16
+
+	// jobTimeout is how long a job may wait and run by default.
+	jobTimeout = time.Minute
+
+	// jobTtl is how long the results are retained by default.
+	jobTtl = 10 * time.Minute
+)
+
+// job is a schedule submitted by Submit. changed is closed and replaced on
+// every state change, to wake up the watchers.
+type job struct {
+	id      string
+	name    string
+	state   pb.JobState
+	reply   *pb.ServerReply
+	expire  time.Time
+	changed chan struct{}
+}
+
+// jobStore retains the jobs until their ttl passes after they are done. The
+// jobs run on ctx, which is cancelled by cancel once the server is deinited.
+type jobStore struct {
+	mutex  sync.Mutex
+	jobs   map[string]*job
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newJobStore() *jobStore {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &jobStore{
+		jobs:   make(map[string]*job),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (j *jobStore) add(name string) *job {
+	buf := make([]byte, jobIDLen)
+	_, _ = rand.Read(buf)
+
+	item := &job{
+		id:      hex.EncodeToString(buf),
+		name:    name,
+		state:   pb.JobState_JOB_STATE_PENDING,
+		changed: make(chan struct{}),
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.sweep(time.Now())
+	j.jobs[item.id] = item
+
+	return item
+}
+
+// get returns the result of the job id submitted by name, and a channel closed
+// on its next change.
+func (j *jobStore) get(id, name string) (*pb.ResultReply, <-chan struct{}, bool) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.sweep(time.Now())
+
+	item, ok := j.jobs[id]
+	if !ok || item.name != name {
+		return nil, nil, false
+	}
+
+	return &pb.ResultReply{Id: item.id, State: item.state, Reply: item.reply}, item.changed, true
+}
+
+// update moves item to state. The reply of a done job is retained for ttl.
+func (j *jobStore) update(item *job, state pb.JobState, reply *pb.ServerReply, ttl time.Duration) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	item.state = state
+	item.reply = reply
+
+	if state == pb.JobState_JOB_STATE_DONE {
+		item.expire = time.Now().Add(ttl)
+	}
+
+	close(item.changed)
+	item.changed = make(chan struct{})
+}
+
+func (j *jobStore) sweep(now time.Time) {
+	for key, val := range j.jobs {
+		if val.state == pb.JobState_JOB_STATE_DONE && now.After(val.expire) {
+			delete(j.jobs, key)
+		}
+	}
+}
+
+// Submit schedules in the background, and returns the job ID at once. The
+// request is checked as in SendServer beforehand.
+func (s *server) Submit(ctx context.Context, in *pb.ServerRequest) (*pb.SubmitReply, error) {
+	c, err := s.prepare(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	// The job is queued against the admission limits as a request would be
+	wait := func(context.Context) (func(), error) { return func() {}, nil }
+
+	if a := s.admitter(); a != nil {
+		if wait, err = a.enqueue(ctx, in.GetMetadata().GetName()); err != nil {
+			return nil, err
+		}
+	}
+
+	item := s.jobs.add(in.GetMetadata().GetName())
+
+	go s.runJob(item, c, in.GetTopN(), wait)
+
+	return &pb.SubmitReply{Id: item.id}, nil
+}
+
+func (s *server) GetResult(_ context.Context, in *pb.ResultRequest) (*pb.ResultReply, error) {
+	reply, _, ok := s.jobs.get(in.GetId(), in.GetMetadata().GetName())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "id: %q is not found", in.GetId())
+	}
+
+	return reply, nil
+}
+
+// WatchResult sends the result on every state change until the job is done.
+func (s *server) WatchResult(in *pb.ResultRequest, stream pb.ServerProto_WatchResultServer) error {
+	for {
+		reply, changed, ok := s.jobs.get(in.GetId(), in.GetMetadata().GetName())
+		if !ok {
+			return status.Errorf(codes.NotFound, "id: %q is not found", in.GetId())
+		}
+		if err := stream.Send(reply); err != nil {
+			return err
+		}
+		if reply.GetState() == pb.JobState_JOB_STATE_DONE {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
+
+// runJob waits for the slot queued by Submit, and runs the job on it. A job
+// which times out or is cancelled by Deinit is done as unavailable.
+func (s *server) runJob(item *job, c *common.Cycle, topN int64, wait func(context.Context) (func(), error)) {
+	cfg := s.config().Config.Spec.Server.Jobs
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = jobTimeout
+	}
+
+	ttl := cfg.Ttl
+	if ttl <= 0 {
+		ttl = jobTtl
+	}
+
+	ctx, cancel := context.WithTimeout(s.jobs.ctx, timeout)
+	defer cancel()
+
+	ctx = common.WithCycle(ctx, c)
+
+	release, err := wait(ctx)
+	if err != nil {
+		s.jobs.update(item, pb.JobState_JOB_STATE_DONE, jobErrorHelper(ctx.Err()), ttl)
+		return
+	}
+
+	defer release()
+
+	s.jobs.update(item, pb.JobState_JOB_STATE_RUNNING, nil, 0)
+
+	reply := s.run(ctx, c, topN)
+
+	// The plugin calls are abandoned once the job is done
+	if err := ctx.Err(); err != nil {
+		reply = jobErrorHelper(err)
+	}
+
+	s.jobs.update(item, pb.JobState_JOB_STATE_DONE, reply, ttl)
+}
+
+func jobErrorHelper(err error) *pb.ServerReply {
+	return &pb.ServerReply{Error: "failed to run job", Code: pb.ErrorCode_ERROR_CODE_UNAVAILABLE, Details: err.Error()}
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pipego/scheduler/config"
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+type testWatchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies []*pb.ResultReply
+}
+
+func (t *testWatchStream) Context() context.Context { return t.ctx }
+
+func (t *testWatchStream) Send(r *pb.ResultReply) error {
+	t.replies = append(t.replies, r)
+	return nil
+}
+
+func TestJobStore(t *testing.T) {
+	j := newJobStore()
+
+	item := j.add("name1")
+
+	r, changed, ok := j.get(item.id, "name1")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, pb.JobState_JOB_STATE_PENDING, r.GetState())
+
+	_, _, ok = j.get(item.id, "name2")
+	assert.Equal(t, false, ok)
+
+	j.update(item, pb.JobState_JOB_STATE_DONE, &pb.ServerReply{Name: "node1"}, 100*time.Millisecond)
+
+	select {
+	case <-changed:
+	default:
+		t.Errorf("not changed")
+	}
+
+	r, _, ok = j.get(item.id, "name1")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, pb.JobState_JOB_STATE_DONE, r.GetState())
+	assert.Equal(t, "node1", r.GetReply().GetName())
+
+	time.Sleep(200 * time.Millisecond)
+
+	_, _, ok = j.get(item.id, "name1")
+	assert.Equal(t, false, ok)
+}
+
+func TestSubmit(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	s.cfg.Scheduler.(*testScheduler).delay = 100 * time.Millisecond
+
+	_, err := s.Submit(ctx, &pb.ServerRequest{Kind: "invalid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	req := &pb.ServerRequest{
+		ApiVersion: ApiVersion,
+		Kind:       Kind,
+		Metadata:   &pb.Metadata{Name: "name1"},
+		Spec: &pb.Spec{
+			Task:  &pb.Task{Name: "node1"},
+			Nodes: []*pb.Node{{Name: "node1"}},
+		},
+	}
+
+	r, err := s.Submit(ctx, req)
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, "", r.GetId())
+
+	res, err := s.GetResult(ctx, &pb.ResultRequest{Id: r.GetId(), Metadata: &pb.Metadata{Name: "name1"}})
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, pb.JobState_JOB_STATE_DONE, res.GetState())
+
+	_, err = s.GetResult(ctx, &pb.ResultRequest{Id: r.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	stream := &testWatchStream{ctx: ctx}
+
+	err = s.WatchResult(&pb.ResultRequest{Id: r.GetId(), Metadata: &pb.Metadata{Name: "name1"}}, stream)
+	assert.Equal(t, nil, err)
+
+	last := stream.replies[len(stream.replies)-1]
+	assert.Equal(t, pb.JobState_JOB_STATE_DONE, last.GetState())
+	assert.Equal(t, "node1", last.GetReply().GetName())
+
+	res, err = s.GetResult(ctx, &pb.ResultRequest{Id: r.GetId(), Metadata: &pb.Metadata{Name: "name1"}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, pb.JobState_JOB_STATE_DONE, res.GetState())
+
+	err = s.WatchResult(&pb.ResultRequest{Id: "invalid"}, stream)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSubmitAdmission(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	s.cfg.Scheduler.(*testScheduler).delay = 200 * time.Millisecond
+	s.admit = newAdmitter(config.Admission{MaxConcurrent: 1, QueueDepth: 1})
+
+	req := &pb.ServerRequest{
+		ApiVersion: ApiVersion,
+		Kind:       Kind,
+		Metadata:   &pb.Metadata{Name: "name1"},
+		Spec: &pb.Spec{
+			Task:  &pb.Task{Name: "node1"},
+			Nodes: []*pb.Node{{Name: "node1"}},
+		},
+	}
+
+	r1, err := s.Submit(ctx, req)
+	assert.Equal(t, nil, err)
+
+	r2, err := s.Submit(ctx, req)
+	assert.Equal(t, nil, err)
+
+	_, err = s.Submit(ctx, req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	for _, item := range []string{r1.GetId(), r2.GetId()} {
+		stream := &testWatchStream{ctx: ctx}
+		err = s.WatchResult(&pb.ResultRequest{Id: item, Metadata: &pb.Metadata{Name: "name1"}}, stream)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "node1", stream.replies[len(stream.replies)-1].GetReply().GetName())
+	}
+
+	_, err = s.Submit(ctx, req)
+	assert.Equal(t, nil, err)
+}
+
+func TestSubmitTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	s.cfg.Scheduler.(*testScheduler).delay = 200 * time.Millisecond
+	s.cfg.Config.Spec.Server.Jobs.Timeout = 50 * time.Millisecond
+	s.admit = newAdmitter(config.Admission{MaxConcurrent: 1, QueueDepth: 1})
+
+	req := &pb.ServerRequest{
+		ApiVersion: ApiVersion,
+		Kind:       Kind,
+		Metadata:   &pb.Metadata{Name: "name1"},
+		Spec: &pb.Spec{
+			Task:  &pb.Task{Name: "node1"},
+			Nodes: []*pb.Node{{Name: "node1"}},
+		},
+	}
+
+	helper := func(id string) *pb.ServerReply {
+		stream := &testWatchStream{ctx: ctx}
+		err := s.WatchResult(&pb.ResultRequest{Id: id, Metadata: &pb.Metadata{Name: "name1"}}, stream)
+		assert.Equal(t, nil, err)
+		return stream.replies[len(stream.replies)-1].GetReply()
+	}
+
+	// The running job and the queued one both time out
+	r1, err := s.Submit(ctx, req)
+	assert.Equal(t, nil, err)
+
+	r2, err := s.Submit(ctx, req)
+	assert.Equal(t, nil, err)
+
+	for _, item := range []string{r1.GetId(), r2.GetId()} {
+		assert.Equal(t, pb.ErrorCode_ERROR_CODE_UNAVAILABLE, helper(item).GetCode())
+	}
+
+	// The jobs are cancelled once the server is deinited
+	s.cfg.Config.Spec.Server.Jobs.Timeout = time.Minute
+
+	r1, err = s.Submit(ctx, req)
+	assert.Equal(t, nil, err)
+
+	r2, err = s.Submit(ctx, req)
+	assert.Equal(t, nil, err)
+
+	time.Sleep(50 * time.Millisecond)
+	s.jobs.cancel()
+
+	for _, item := range []string{r1.GetId(), r2.GetId()} {
+		assert.Equal(t, pb.ErrorCode_ERROR_CODE_UNAVAILABLE, helper(item).GetCode())
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"net"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	schemeTcp  = "tcp"
+	schemeUnix = "unix"
+)
+
+// listen binds address, which is a url of tcp://host:port or unix:///path, or
+// host:port for tcp.
+func listen(address string) (net.Listener, error) {
+	scheme, addr, ok := strings.Cut(address, "://")
+	if !ok {
+		scheme, addr = schemeTcp, address
+	}
+
+	switch scheme {
+	case schemeTcp:
+		return net.Listen(schemeTcp, addr)
+	case schemeUnix:
+		if addr == "" {
+			return nil, errors.New("invalid path")
+		}
+		if err := removeSocket(addr); err != nil {
+			return nil, err
+		}
+		return net.Listen(schemeUnix, addr)
+	default:
+		return nil, errors.New("invalid scheme " + scheme)
+	}
+}
+
+// removeSocket removes the socket at path left by a previous run, unless it is
+// still served.
+func removeSocket(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+
+	if fi.Mode()&os.ModeSocket == 0 {
+		return errors.New("invalid socket " + path)
+	}
+
+	if c, err := net.Dial(schemeUnix, path); err == nil {
+		_ = c.Close()
+		return errors.New("socket in use " + path)
+	}
+
+	return os.Remove(path)
+}
+
+// listenHelper binds every address, or none if any fails.
+func listenHelper(addresses []string) ([]net.Listener, error) {
+	var buf []net.Listener
+
+	for _, item := range addresses {
+		lis, err := listen(item)
+		if err != nil {
+			closeHelper(buf)
+			return nil, errors.Wrap(err, "failed to listen "+item)
+		}
+		buf = append(buf, lis)
+	}
+
+	return buf, nil
+}
+
+func closeHelper(listeners []net.Listener) {
+	for _, item := range listeners {
+		_ = item.Close()
+	}
+}
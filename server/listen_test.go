@@ -0,0 +1,61 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListen(t *testing.T) {
+	lis, err := listen("127.0.0.1:0")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "tcp", lis.Addr().Network())
+	_ = lis.Close()
+
+	lis, err = listen("tcp://127.0.0.1:0")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "tcp", lis.Addr().Network())
+	_ = lis.Close()
+
+	_, err = listen("udp://127.0.0.1:0")
+	assert.NotEqual(t, nil, err)
+
+	_, err = listen("unix://")
+	assert.NotEqual(t, nil, err)
+
+	path := filepath.Join(t.TempDir(), "scheduler.sock")
+
+	lis, err = listen("unix://" + path)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "unix", lis.Addr().Network())
+
+	_, err = listen("unix://" + path)
+	assert.NotEqual(t, nil, err)
+
+	_ = lis.Close()
+
+	file := filepath.Join(t.TempDir(), "scheduler.txt")
+	_ = os.WriteFile(file, []byte("scheduler"), 0600)
+
+	_, err = listen("unix://" + file)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestListenHelper(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.sock")
+
+	lis, err := listenHelper([]string{"127.0.0.1:0", "unix://" + path})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(lis))
+	closeHelper(lis)
+
+	_, err = listenHelper([]string{"unix://" + path, "invalid://"})
+	assert.NotEqual(t, nil, err)
+
+	// The bound listeners are closed on failure
+	lis, err = listenHelper([]string{"unix://" + path})
+	assert.Equal(t, nil, err)
+	closeHelper(lis)
+}
@@ -36,6 +36,46 @@ func (m *MockServerProtoClient) EXPECT() *MockServerProtoClientMockRecorder {
 	return m.recorder
 }
 
+// Explain mocks base method.
+func (m *MockServerProtoClient) Explain(arg0 context.Context, arg1 *server.ServerRequest, arg2 ...grpc.CallOption) (*server.ExplainReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Explain", varargs...)
+	ret0, _ := ret[0].(*server.ExplainReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Explain indicates an expected call of Explain.
+func (mr *MockServerProtoClientMockRecorder) Explain(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Explain", reflect.TypeOf((*MockServerProtoClient)(nil).Explain), varargs...)
+}
+
+// GetResult mocks base method.
+func (m *MockServerProtoClient) GetResult(arg0 context.Context, arg1 *server.ResultRequest, arg2 ...grpc.CallOption) (*server.ResultReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetResult", varargs...)
+	ret0, _ := ret[0].(*server.ResultReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResult indicates an expected call of GetResult.
+func (mr *MockServerProtoClientMockRecorder) GetResult(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResult", reflect.TypeOf((*MockServerProtoClient)(nil).GetResult), varargs...)
+}
+
 // SendServer mocks base method.
 func (m *MockServerProtoClient) SendServer(arg0 context.Context, arg1 *server.ServerRequest, arg2 ...grpc.CallOption) (*server.ServerReply, error) {
 	m.ctrl.T.Helper()
@@ -55,3 +95,63 @@ func (mr *MockServerProtoClientMockRecorder) SendServer(arg0, arg1 interface{},
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendServer", reflect.TypeOf((*MockServerProtoClient)(nil).SendServer), varargs...)
 }
+
+// SendServerBatch mocks base method.
+func (m *MockServerProtoClient) SendServerBatch(arg0 context.Context, arg1 *server.ServerBatchRequest, arg2 ...grpc.CallOption) (*server.ServerBatchReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SendServerBatch", varargs...)
+	ret0, _ := ret[0].(*server.ServerBatchReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendServerBatch indicates an expected call of SendServerBatch.
+func (mr *MockServerProtoClientMockRecorder) SendServerBatch(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendServerBatch", reflect.TypeOf((*MockServerProtoClient)(nil).SendServerBatch), varargs...)
+}
+
+// Submit mocks base method.
+func (m *MockServerProtoClient) Submit(arg0 context.Context, arg1 *server.ServerRequest, arg2 ...grpc.CallOption) (*server.SubmitReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Submit", varargs...)
+	ret0, _ := ret[0].(*server.SubmitReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockServerProtoClientMockRecorder) Submit(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockServerProtoClient)(nil).Submit), varargs...)
+}
+
+// WatchResult mocks base method.
+func (m *MockServerProtoClient) WatchResult(arg0 context.Context, arg1 *server.ResultRequest, arg2 ...grpc.CallOption) (server.ServerProto_WatchResultClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchResult", varargs...)
+	ret0, _ := ret[0].(server.ServerProto_WatchResultClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchResult indicates an expected call of WatchResult.
+func (mr *MockServerProtoClientMockRecorder) WatchResult(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchResult", reflect.TypeOf((*MockServerProtoClient)(nil).WatchResult), varargs...)
+}
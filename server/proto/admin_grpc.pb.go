@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: server/proto/admin.proto
+
+package server
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	AdminProto_GetReloadStatus_FullMethodName = "/scheduler.AdminProto/GetReloadStatus"
+	AdminProto_ListPlugins_FullMethodName     = "/scheduler.AdminProto/ListPlugins"
+	AdminProto_GetConfig_FullMethodName       = "/scheduler.AdminProto/GetConfig"
+	AdminProto_GetVersion_FullMethodName      = "/scheduler.AdminProto/GetVersion"
+)
+
+// AdminProtoClient is the client API for AdminProto service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminProtoClient interface {
+	GetReloadStatus(ctx context.Context, in *ReloadStatusRequest, opts ...grpc.CallOption) (*ReloadStatusReply, error)
+	ListPlugins(ctx context.Context, in *ListPluginsRequest, opts ...grpc.CallOption) (*ListPluginsReply, error)
+	GetConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigReply, error)
+	GetVersion(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionReply, error)
+}
+
+type adminProtoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminProtoClient(cc grpc.ClientConnInterface) AdminProtoClient {
+	return &adminProtoClient{cc}
+}
+
+func (c *adminProtoClient) GetReloadStatus(ctx context.Context, in *ReloadStatusRequest, opts ...grpc.CallOption) (*ReloadStatusReply, error) {
+	out := new(ReloadStatusReply)
+	err := c.cc.Invoke(ctx, AdminProto_GetReloadStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminProtoClient) ListPlugins(ctx context.Context, in *ListPluginsRequest, opts ...grpc.CallOption) (*ListPluginsReply, error) {
+	out := new(ListPluginsReply)
+	err := c.cc.Invoke(ctx, AdminProto_ListPlugins_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminProtoClient) GetConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigReply, error) {
+	out := new(ConfigReply)
+	err := c.cc.Invoke(ctx, AdminProto_GetConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminProtoClient) GetVersion(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionReply, error) {
+	out := new(VersionReply)
+	err := c.cc.Invoke(ctx, AdminProto_GetVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminProtoServer is the server API for AdminProto service.
+// All implementations must embed UnimplementedAdminProtoServer
+// for forward compatibility
+type AdminProtoServer interface {
+	GetReloadStatus(context.Context, *ReloadStatusRequest) (*ReloadStatusReply, error)
+	ListPlugins(context.Context, *ListPluginsRequest) (*ListPluginsReply, error)
+	GetConfig(context.Context, *ConfigRequest) (*ConfigReply, error)
+	GetVersion(context.Context, *VersionRequest) (*VersionReply, error)
+	mustEmbedUnimplementedAdminProtoServer()
+}
+
+// UnimplementedAdminProtoServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminProtoServer struct {
+}
+
+func (UnimplementedAdminProtoServer) GetReloadStatus(context.Context, *ReloadStatusRequest) (*ReloadStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReloadStatus not implemented")
+}
+func (UnimplementedAdminProtoServer) ListPlugins(context.Context, *ListPluginsRequest) (*ListPluginsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlugins not implemented")
+}
+func (UnimplementedAdminProtoServer) GetConfig(context.Context, *ConfigRequest) (*ConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedAdminProtoServer) GetVersion(context.Context, *VersionRequest) (*VersionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedAdminProtoServer) mustEmbedUnimplementedAdminProtoServer() {}
+
+// UnsafeAdminProtoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminProtoServer will
+// result in compilation errors.
+type UnsafeAdminProtoServer interface {
+	mustEmbedUnimplementedAdminProtoServer()
+}
+
+func RegisterAdminProtoServer(s grpc.ServiceRegistrar, srv AdminProtoServer) {
+	s.RegisterService(&AdminProto_ServiceDesc, srv)
+}
+
+func _AdminProto_GetReloadStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminProtoServer).GetReloadStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminProto_GetReloadStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminProtoServer).GetReloadStatus(ctx, req.(*ReloadStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminProto_ListPlugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPluginsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminProtoServer).ListPlugins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminProto_ListPlugins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminProtoServer).ListPlugins(ctx, req.(*ListPluginsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminProto_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminProtoServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminProto_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminProtoServer).GetConfig(ctx, req.(*ConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminProto_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminProtoServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminProto_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminProtoServer).GetVersion(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminProto_ServiceDesc is the grpc.ServiceDesc for AdminProto service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminProto_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scheduler.AdminProto",
+	HandlerType: (*AdminProtoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetReloadStatus",
+			Handler:    _AdminProto_GetReloadStatus_Handler,
+		},
+		{
+			MethodName: "ListPlugins",
+			Handler:    _AdminProto_ListPlugins_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _AdminProto_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _AdminProto_GetVersion_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "server/proto/admin.proto",
+}
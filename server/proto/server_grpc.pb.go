@@ -19,7 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ServerProto_SendServer_FullMethodName = "/scheduler.ServerProto/SendServer"
+	ServerProto_SendServer_FullMethodName      = "/scheduler.ServerProto/SendServer"
+	ServerProto_SendServerBatch_FullMethodName = "/scheduler.ServerProto/SendServerBatch"
+	ServerProto_Explain_FullMethodName         = "/scheduler.ServerProto/Explain"
+	ServerProto_Submit_FullMethodName          = "/scheduler.ServerProto/Submit"
+	ServerProto_GetResult_FullMethodName       = "/scheduler.ServerProto/GetResult"
+	ServerProto_WatchResult_FullMethodName     = "/scheduler.ServerProto/WatchResult"
 )
 
 // ServerProtoClient is the client API for ServerProto service.
@@ -27,6 +32,11 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ServerProtoClient interface {
 	SendServer(ctx context.Context, in *ServerRequest, opts ...grpc.CallOption) (*ServerReply, error)
+	SendServerBatch(ctx context.Context, in *ServerBatchRequest, opts ...grpc.CallOption) (*ServerBatchReply, error)
+	Explain(ctx context.Context, in *ServerRequest, opts ...grpc.CallOption) (*ExplainReply, error)
+	Submit(ctx context.Context, in *ServerRequest, opts ...grpc.CallOption) (*SubmitReply, error)
+	GetResult(ctx context.Context, in *ResultRequest, opts ...grpc.CallOption) (*ResultReply, error)
+	WatchResult(ctx context.Context, in *ResultRequest, opts ...grpc.CallOption) (ServerProto_WatchResultClient, error)
 }
 
 type serverProtoClient struct {
@@ -46,11 +56,84 @@ func (c *serverProtoClient) SendServer(ctx context.Context, in *ServerRequest, o
 	return out, nil
 }
 
+func (c *serverProtoClient) SendServerBatch(ctx context.Context, in *ServerBatchRequest, opts ...grpc.CallOption) (*ServerBatchReply, error) {
+	out := new(ServerBatchReply)
+	err := c.cc.Invoke(ctx, ServerProto_SendServerBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverProtoClient) Explain(ctx context.Context, in *ServerRequest, opts ...grpc.CallOption) (*ExplainReply, error) {
+	out := new(ExplainReply)
+	err := c.cc.Invoke(ctx, ServerProto_Explain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverProtoClient) Submit(ctx context.Context, in *ServerRequest, opts ...grpc.CallOption) (*SubmitReply, error) {
+	out := new(SubmitReply)
+	err := c.cc.Invoke(ctx, ServerProto_Submit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverProtoClient) GetResult(ctx context.Context, in *ResultRequest, opts ...grpc.CallOption) (*ResultReply, error) {
+	out := new(ResultReply)
+	err := c.cc.Invoke(ctx, ServerProto_GetResult_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverProtoClient) WatchResult(ctx context.Context, in *ResultRequest, opts ...grpc.CallOption) (ServerProto_WatchResultClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ServerProto_ServiceDesc.Streams[0], ServerProto_WatchResult_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serverProtoWatchResultClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ServerProto_WatchResultClient interface {
+	Recv() (*ResultReply, error)
+	grpc.ClientStream
+}
+
+type serverProtoWatchResultClient struct {
+	grpc.ClientStream
+}
+
+func (x *serverProtoWatchResultClient) Recv() (*ResultReply, error) {
+	m := new(ResultReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ServerProtoServer is the server API for ServerProto service.
 // All implementations must embed UnimplementedServerProtoServer
 // for forward compatibility
 type ServerProtoServer interface {
 	SendServer(context.Context, *ServerRequest) (*ServerReply, error)
+	SendServerBatch(context.Context, *ServerBatchRequest) (*ServerBatchReply, error)
+	Explain(context.Context, *ServerRequest) (*ExplainReply, error)
+	Submit(context.Context, *ServerRequest) (*SubmitReply, error)
+	GetResult(context.Context, *ResultRequest) (*ResultReply, error)
+	WatchResult(*ResultRequest, ServerProto_WatchResultServer) error
 	mustEmbedUnimplementedServerProtoServer()
 }
 
@@ -61,6 +144,21 @@ type UnimplementedServerProtoServer struct {
 func (UnimplementedServerProtoServer) SendServer(context.Context, *ServerRequest) (*ServerReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendServer not implemented")
 }
+func (UnimplementedServerProtoServer) SendServerBatch(context.Context, *ServerBatchRequest) (*ServerBatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendServerBatch not implemented")
+}
+func (UnimplementedServerProtoServer) Explain(context.Context, *ServerRequest) (*ExplainReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Explain not implemented")
+}
+func (UnimplementedServerProtoServer) Submit(context.Context, *ServerRequest) (*SubmitReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Submit not implemented")
+}
+func (UnimplementedServerProtoServer) GetResult(context.Context, *ResultRequest) (*ResultReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResult not implemented")
+}
+func (UnimplementedServerProtoServer) WatchResult(*ResultRequest, ServerProto_WatchResultServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchResult not implemented")
+}
 func (UnimplementedServerProtoServer) mustEmbedUnimplementedServerProtoServer() {}
 
 // UnsafeServerProtoServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +190,99 @@ func _ServerProto_SendServer_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerProto_SendServerBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerProtoServer).SendServerBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerProto_SendServerBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerProtoServer).SendServerBatch(ctx, req.(*ServerBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerProto_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerProtoServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerProto_Explain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerProtoServer).Explain(ctx, req.(*ServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerProto_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerProtoServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerProto_Submit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerProtoServer).Submit(ctx, req.(*ServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerProto_GetResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerProtoServer).GetResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerProto_GetResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerProtoServer).GetResult(ctx, req.(*ResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerProto_WatchResult_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResultRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServerProtoServer).WatchResult(m, &serverProtoWatchResultServer{stream})
+}
+
+type ServerProto_WatchResultServer interface {
+	Send(*ResultReply) error
+	grpc.ServerStream
+}
+
+type serverProtoWatchResultServer struct {
+	grpc.ServerStream
+}
+
+func (x *serverProtoWatchResultServer) Send(m *ResultReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ServerProto_ServiceDesc is the grpc.ServiceDesc for ServerProto service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,7 +294,29 @@ var ServerProto_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendServer",
 			Handler:    _ServerProto_SendServer_Handler,
 		},
+		{
+			MethodName: "SendServerBatch",
+			Handler:    _ServerProto_SendServerBatch_Handler,
+		},
+		{
+			MethodName: "Explain",
+			Handler:    _ServerProto_Explain_Handler,
+		},
+		{
+			MethodName: "Submit",
+			Handler:    _ServerProto_Submit_Handler,
+		},
+		{
+			MethodName: "GetResult",
+			Handler:    _ServerProto_GetResult_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchResult",
+			Handler:       _ServerProto_WatchResult_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "server/proto/server.proto",
 }
@@ -2,47 +2,120 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"math"
 	"net"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 
 	"github.com/pipego/scheduler/common"
 	"github.com/pipego/scheduler/config"
 	"github.com/pipego/scheduler/logger"
+	"github.com/pipego/scheduler/plugin"
 	"github.com/pipego/scheduler/scheduler"
 	pb "github.com/pipego/scheduler/server/proto"
 )
 
 const (
 	Kind = "scheduler"
+
+	RequestIDKey = "x-request-id"
+	requestIDLen = 8
+
+	httpTimeout = 10 * time.Second
+
+	drainTimeout = 30 * time.Second
+)
+
+var (
+	errorCodes = map[scheduler.Code]pb.ErrorCode{
+		scheduler.CodeOK:            pb.ErrorCode_ERROR_CODE_OK,
+		scheduler.CodeInvalidNodes:  pb.ErrorCode_ERROR_CODE_INVALID_NODES,
+		scheduler.CodeFetchFailed:   pb.ErrorCode_ERROR_CODE_FETCH_FAILED,
+		scheduler.CodeFilterFailed:  pb.ErrorCode_ERROR_CODE_FILTER_FAILED,
+		scheduler.CodeUnschedulable: pb.ErrorCode_ERROR_CODE_UNSCHEDULABLE,
+		scheduler.CodeScoreFailed:   pb.ErrorCode_ERROR_CODE_SCORE_FAILED,
+		scheduler.CodeSelectFailed:  pb.ErrorCode_ERROR_CODE_SELECT_FAILED,
+		scheduler.CodeUnavailable:   pb.ErrorCode_ERROR_CODE_UNAVAILABLE,
+	}
+
+	statusCodes = map[pb.ErrorCode]codes.Code{
+		pb.ErrorCode_ERROR_CODE_OK:            codes.OK,
+		pb.ErrorCode_ERROR_CODE_INVALID_KIND:  codes.InvalidArgument,
+		pb.ErrorCode_ERROR_CODE_INVALID_SPEC:  codes.InvalidArgument,
+		pb.ErrorCode_ERROR_CODE_INVALID_NODES: codes.InvalidArgument,
+		pb.ErrorCode_ERROR_CODE_FETCH_FAILED:  codes.Internal,
+		pb.ErrorCode_ERROR_CODE_FILTER_FAILED: codes.Internal,
+		pb.ErrorCode_ERROR_CODE_UNSCHEDULABLE: codes.FailedPrecondition,
+		pb.ErrorCode_ERROR_CODE_SCORE_FAILED:  codes.Internal,
+		pb.ErrorCode_ERROR_CODE_SELECT_FAILED: codes.Internal,
+		pb.ErrorCode_ERROR_CODE_UNAVAILABLE:   codes.Unavailable,
+	}
 )
 
 type Server interface {
 	Init(context.Context) error
 	Deinit(context.Context) error
+	Reload(context.Context, *Config) error
+	Rollback(context.Context, string, error)
 	Run(context.Context) error
 }
 
+// Config of the server. Addresses are the listen urls of the gRPC server, see
+// listen for the schemes, and Listeners are served along with them, e.g. an
+// in-memory listener in tests.
 type Config struct {
-	Address   string
-	Config    config.Config
-	Logger    logger.Logger
-	Scheduler scheduler.Scheduler
+	Addresses   []string
+	Listeners   []net.Listener
+	HttpAddress string
+	Revision    string
+	Config      config.Config
+	Logger      logger.Logger
+	Plugin      plugin.Plugin
+	Scheduler   scheduler.Scheduler
 }
 
 type server struct {
-	cfg  *Config
-	srv  *grpc.Server
-	task *common.Task
+	cfg     *Config
+	mutex   sync.RWMutex
+	wg      *sync.WaitGroup
+	retired sync.WaitGroup
+	reload  reloadStatus
+	admit   *admitter
+	jobs    *jobStore
+	dedup   *idempotency
+	srv     *grpc.Server
+	lis     []net.Listener
+	http    *http.Server
+	httpLis net.Listener
+	tls     *tlsLoader
+	health  *health.Server
+	done    chan struct{}
 	pb.UnimplementedServerProtoServer
 }
 
 func New(_ context.Context, cfg *Config) Server {
 	return &server{
-		cfg: cfg,
+		cfg:   cfg,
+		wg:    &sync.WaitGroup{},
+		jobs:  newJobStore(),
+		dedup: newIdempotency(),
 	}
 }
 
@@ -51,103 +124,582 @@ func DefaultConfig() *Config {
 }
 
 func (s *server) Init(ctx context.Context) error {
-	if err := s.cfg.Logger.Init(ctx); err != nil {
-		return errors.Wrap(err, "failed to init logger")
+	s.health = health.NewServer()
+	s.setServing(false)
+
+	// Mutual TLS is not served as plaintext if the server cert is missing
+	if c := s.cfg.Config.Spec.Server.Tls; c.CaFile != "" && (c.CertFile == "" || c.KeyFile == "") {
+		return errors.New("invalid tls")
 	}
 
-	if err := s.cfg.Scheduler.Init(ctx); err != nil {
-		return errors.Wrap(err, "failed to init scheduler")
+	if err := s.initPipe(ctx, s.cfg); err != nil {
+		return errors.Wrap(err, "failed to init pipe")
 	}
 
-	options := []grpc.ServerOption{grpc.MaxRecvMsgSize(math.MaxInt32), grpc.MaxSendMsgSize(math.MaxInt32)}
+	s.admit = newAdmitter(s.cfg.Config.Spec.Server.Admission)
+	s.done = make(chan struct{})
+
+	options := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(math.MaxInt32),
+		grpc.MaxSendMsgSize(math.MaxInt32),
+		grpc.ChainUnaryInterceptor(s.unaryInterceptors()...),
+		grpc.ChainStreamInterceptor(s.streamInterceptors()...),
+	}
+
+	if c := s.cfg.Config.Spec.Server.Tls; c.CertFile != "" || c.KeyFile != "" {
+		t, err := newTlsLoader(c, s.cfg.Logger)
+		if err != nil {
+			return errors.Wrap(err, "failed to init tls")
+		}
+		if err := t.watch(s.done); err != nil {
+			return errors.Wrap(err, "failed to watch tls")
+		}
+		options = append(options, grpc.Creds(credentials.NewTLS(t.config("h2"))))
+		s.tls = t
+	}
 
 	s.srv = grpc.NewServer(options...)
 	pb.RegisterServerProtoServer(s.srv, s)
+	pb.RegisterAdminProtoServer(s.srv, &admin{server: s})
+	healthpb.RegisterHealthServer(s.srv, s.health)
+	reflection.Register(s.srv)
+
+	if s.cfg.HttpAddress != "" {
+		s.http = &http.Server{
+			Handler:           s.httpHandler(),
+			ReadHeaderTimeout: httpTimeout,
+		}
+		if s.tls != nil {
+			s.http.TLSConfig = s.tls.config("http/1.1")
+		}
+	}
+
+	if err := s.listen(); err != nil {
+		return errors.Wrap(err, "failed to listen")
+	}
+
+	// Serve only once every enabled plugin is dispensed
+	s.setServing(s.cfg.Scheduler.Health(ctx) == nil)
+
+	go s.watchHealth(ctx, s.done)
 
 	return nil
 }
 
+// Deinit stops accepting requests, and drains the requests in flight for up to
+// spec.server.shutdown.timeout before the plugins are killed.
 func (s *server) Deinit(ctx context.Context) error {
-	s.srv.Stop()
-	_ = s.cfg.Logger.Deinit(ctx)
-	_ = s.cfg.Scheduler.Deinit(ctx)
+	// Report NOT_SERVING while the pipeline is torn down
+	s.health.Shutdown()
+	close(s.done)
+
+	cfg := s.config()
+
+	ctx, cancel := context.WithTimeout(ctx, drainHelper(cfg))
+	defer cancel()
+
+	if s.http != nil {
+		if err := s.http.Shutdown(ctx); err != nil {
+			_ = s.http.Close()
+		}
+		_ = s.httpLis.Close()
+	}
+
+	stopped := make(chan struct{})
+
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		cfg.Logger.Warn("server", zap.String("shutdown", "drain timed out"))
+		s.srv.Stop()
+	}
+
+	// Close the listeners in case Run was not called
+	closeHelper(s.lis)
+
+	// Cancel the submitted jobs, which are done as unavailable
+	s.jobs.cancel()
+
+	// Wait for the configs swapped out by Reload to be deinited
+	s.retired.Wait()
+
+	_ = cfg.Scheduler.Deinit(ctx)
+	_ = cfg.Logger.Deinit(ctx)
+
+	return nil
+}
+
+// Reload inits the logger and scheduler of cfg in the background, and swaps
+// them in behind the running server. The old ones are deinited in the
+// background once their requests in flight finish, so that Reload does not
+// block on them. The listeners are kept, so that addresses and
+// TLS settings only take effect on restart. The admitter is kept unless the
+// admission changes, so that its slots and rate buckets carry over. If cfg
+// fails to init, the server rolls back to the current config.
+func (s *server) Reload(ctx context.Context, cfg *Config) error {
+	if err := s.initPipe(ctx, cfg); err != nil {
+		s.Rollback(ctx, cfg.Revision, err)
+		return errors.Wrap(err, "failed to init pipe")
+	}
+
+	if err := cfg.Scheduler.Health(ctx); err != nil {
+		_ = cfg.Scheduler.Deinit(ctx)
+		_ = cfg.Logger.Deinit(ctx)
+		s.Rollback(ctx, cfg.Revision, err)
+		return errors.Wrap(err, "failed to check health")
+	}
+
+	s.mutex.Lock()
+	old, wg := s.cfg, s.wg
+	cfg.Addresses, cfg.Listeners, cfg.HttpAddress = old.Addresses, old.Listeners, old.HttpAddress
+	s.cfg, s.wg = cfg, &sync.WaitGroup{}
+	if !reflect.DeepEqual(old.Config.Spec.Server.Admission, cfg.Config.Spec.Server.Admission) {
+		s.admit = newAdmitter(cfg.Config.Spec.Server.Admission)
+	}
+	s.reload = reloadStatus{}
+	s.mutex.Unlock()
+
+	s.setServing(true)
+	cfg.Logger.Info("server", zap.String("reload", "swapped"), zap.String("revision", cfg.Revision))
+
+	s.retired.Add(1)
+
+	go func() {
+		defer s.retired.Done()
+		s.retire(context.WithoutCancel(ctx), old, wg)
+	}()
 
 	return nil
 }
 
+// retire waits for the requests in flight on the old config to finish, up to
+// its drain timeout, and then deinits its scheduler and logger.
+func (s *server) retire(ctx context.Context, old *Config, wg *sync.WaitGroup) {
+	ctx, cancel := context.WithTimeout(ctx, drainHelper(old))
+	defer cancel()
+
+	drained := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		old.Logger.Warn("server", zap.String("reload", "drain timed out"))
+	}
+
+	_ = old.Scheduler.Deinit(ctx)
+	_ = old.Logger.Deinit(ctx)
+}
+
+// listen binds the listeners in Init, so that the server fails to start if any
+// address is not available.
+func (s *server) listen() error {
+	if len(s.cfg.Addresses) == 0 && len(s.cfg.Listeners) == 0 {
+		return errors.New("invalid address")
+	}
+
+	lis, err := listenHelper(s.cfg.Addresses)
+	if err != nil {
+		return err
+	}
+
+	lis = append(lis, s.cfg.Listeners...)
+
+	if s.http != nil {
+		s.httpLis, err = listen(s.cfg.HttpAddress)
+		if err != nil {
+			closeHelper(lis)
+			return errors.Wrap(err, "failed to listen http")
+		}
+	}
+
+	s.lis = lis
+
+	return nil
+}
+
+// Run serves every listener, and returns once all of them are stopped.
 func (s *server) Run(_ context.Context) error {
-	lis, _ := net.Listen("tcp", s.cfg.Address)
-	return s.srv.Serve(lis)
+	if s.http != nil {
+		go func() {
+			if s.tls != nil {
+				_ = s.http.ServeTLS(s.httpLis, "", "")
+			} else {
+				_ = s.http.Serve(s.httpLis)
+			}
+		}()
+	}
+
+	errs := make(chan error, len(s.lis))
+
+	for _, item := range s.lis {
+		go func(lis net.Listener) {
+			errs <- s.srv.Serve(lis)
+		}(item)
+	}
+
+	for range s.lis {
+		if err := <-errs; err != nil {
+			return errors.Wrap(err, "failed to serve")
+		}
+	}
+
+	return nil
+}
+
+func (s *server) initPipe(ctx context.Context, cfg *Config) error {
+	if err := cfg.Logger.Init(ctx); err != nil {
+		return errors.Wrap(err, "failed to init logger")
+	}
+
+	if err := cfg.Scheduler.Init(ctx); err != nil {
+		_ = cfg.Scheduler.Deinit(ctx)
+		_ = cfg.Logger.Deinit(ctx)
+		return errors.Wrap(err, "failed to init scheduler")
+	}
+
+	return nil
 }
 
+// config returns the current config, which is swapped by Reload.
+func (s *server) config() *Config {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.cfg
+}
+
+// acquire returns the current config, and keeps Reload from retiring it until
+// release is called.
+func (s *server) acquire() (cfg *Config, release func()) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	s.wg.Add(1)
+
+	return s.cfg, s.wg.Done
+}
+
+func (s *server) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{s.unaryAuth, s.unaryAdmit}
+}
+
+func (s *server) streamInterceptors() []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{s.streamAuth}
+}
+
+// SendServer schedules in. If the idempotency window is set, the decision is
+// reused by the same requests with the same idempotency key within the window,
+// and other requests with the key are rejected.
 func (s *server) SendServer(ctx context.Context, in *pb.ServerRequest) (*pb.ServerReply, error) {
-	var nodes []*common.Node
+	c, err := s.prepare(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	window := s.config().Config.Spec.Server.Idempotency.Window
 
+	return s.dedup.do(ctx, idempotencyKey(ctx, in), idempotencyHash(in), window, func() (*pb.ServerReply, error) {
+		reply := s.run(common.WithCycle(ctx, c), c, in.GetTopN())
+		// The plugin calls are abandoned once the deadline is exceeded
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		if err := statusHelper(reply.GetCode(), reply.GetError(), reply); err != nil {
+			return nil, err
+		}
+		return reply, nil
+	})
+}
+
+// prepare checks in, and returns the cycle to schedule it in. The error is a
+// gRPC status with the ServerReply attached.
+func (s *server) prepare(ctx context.Context, in *pb.ServerRequest) (*common.Cycle, error) {
 	if in.GetKind() != Kind {
-		return &pb.ServerReply{Error: "invalid kind"}, nil
+		reply := &pb.ServerReply{Error: "invalid kind", Code: pb.ErrorCode_ERROR_CODE_INVALID_KIND, Details: kindDetails(in.GetKind())}
+		return nil, statusHelper(reply.GetCode(), reply.GetError(), reply)
 	}
 
-	nodes, err := s.sendHelper(ctx, in.GetSpec().GetTask(), in.GetSpec().GetNodes())
+	if v := validateRequest(in); len(v) != 0 {
+		reply := &pb.ServerReply{Error: "invalid spec", Code: pb.ErrorCode_ERROR_CODE_INVALID_SPEC, Details: violationHelper(v)}
+		return nil, statusHelper(reply.GetCode(), reply.GetError(), reply, badRequestHelper(v))
+	}
+
+	task, nodes, err := s.sendHelper(ctx, in.GetSpec().GetTask(), in.GetSpec().GetNodes())
 	if err != nil {
-		return &pb.ServerReply{Error: "invalid spec"}, nil
+		reply := &pb.ServerReply{Error: "invalid spec", Code: pb.ErrorCode_ERROR_CODE_INVALID_SPEC, Details: err.Error()}
+		return nil, statusHelper(reply.GetCode(), reply.GetError(), reply)
 	}
 
-	res := s.cfg.Scheduler.Run(ctx, s.task, nodes)
-	_ = s.writeLog(ctx, s.task, nodes, res)
+	return common.NewCycle(requestID(ctx), task, nodes), nil
+}
 
-	return &pb.ServerReply{
-		Name:  res.Name,
-		Error: res.Error,
-	}, nil
+// run schedules the task of c on the current config.
+func (s *server) run(ctx context.Context, c *common.Cycle, topN int64) *pb.ServerReply {
+	cfg, release := s.acquire()
+	defer release()
+
+	res := cfg.Scheduler.Run(ctx, c.Task, c.Nodes)
+	res.Candidates = candidateHelper(res.Candidates, topN)
+	_ = s.writeLog(ctx, cfg, c, res)
+
+	return replyHelper(&res)
+}
+
+func (s *server) SendServerBatch(ctx context.Context, in *pb.ServerBatchRequest) (*pb.ServerBatchReply, error) {
+	if in.GetKind() != Kind {
+		reply := &pb.ServerBatchReply{Error: "invalid kind", Code: pb.ErrorCode_ERROR_CODE_INVALID_KIND, Details: kindDetails(in.GetKind())}
+		return nil, statusHelper(reply.GetCode(), reply.GetError(), reply)
+	}
+
+	if v := validateBatchRequest(in); len(v) != 0 {
+		reply := &pb.ServerBatchReply{Error: "invalid spec", Code: pb.ErrorCode_ERROR_CODE_INVALID_SPEC, Details: violationHelper(v)}
+		return nil, statusHelper(reply.GetCode(), reply.GetError(), reply, badRequestHelper(v))
+	}
+
+	tasks, nodes, err := s.sendBatchHelper(ctx, in.GetSpec().GetTasks(), in.GetSpec().GetNodes())
+	if err != nil {
+		reply := &pb.ServerBatchReply{Error: "invalid spec", Code: pb.ErrorCode_ERROR_CODE_INVALID_SPEC, Details: err.Error()}
+		return nil, statusHelper(reply.GetCode(), reply.GetError(), reply)
+	}
+
+	c := common.NewCycle(requestID(ctx), nil, nodes)
+	ctx = common.WithCycle(ctx, c)
+
+	cfg, release := s.acquire()
+	defer release()
+
+	res := cfg.Scheduler.RunBatch(ctx, tasks, c.Nodes)
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	for i := range res {
+		res[i].Candidates = candidateHelper(res[i].Candidates, in.GetTopN())
+	}
+	_ = s.writeBatchLog(ctx, cfg, c, tasks, res)
+
+	replies := make([]*pb.ServerReply, len(res))
+	for i := range res {
+		replies[i] = replyHelper(&res[i])
+	}
+
+	return &pb.ServerBatchReply{Replies: replies}, nil
 }
 
-func (s *server) sendHelper(_ context.Context, task *pb.Task, nodes []*pb.Node) ([]*common.Node, error) {
+func (s *server) Explain(ctx context.Context, in *pb.ServerRequest) (*pb.ExplainReply, error) {
+	if in.GetKind() != Kind {
+		reply := &pb.ExplainReply{Error: "invalid kind", Code: pb.ErrorCode_ERROR_CODE_INVALID_KIND, Details: kindDetails(in.GetKind())}
+		return nil, statusHelper(reply.GetCode(), reply.GetError(), reply)
+	}
+
+	if v := validateRequest(in); len(v) != 0 {
+		reply := &pb.ExplainReply{Error: "invalid spec", Code: pb.ErrorCode_ERROR_CODE_INVALID_SPEC, Details: violationHelper(v)}
+		return nil, statusHelper(reply.GetCode(), reply.GetError(), reply, badRequestHelper(v))
+	}
+
+	task, nodes, err := s.sendHelper(ctx, in.GetSpec().GetTask(), in.GetSpec().GetNodes())
+	if err != nil {
+		reply := &pb.ExplainReply{Error: "invalid spec", Code: pb.ErrorCode_ERROR_CODE_INVALID_SPEC, Details: err.Error()}
+		return nil, statusHelper(reply.GetCode(), reply.GetError(), reply)
+	}
+
+	c := common.NewCycle(requestID(ctx), task, nodes)
+	ctx = common.WithCycle(ctx, c)
+
+	cfg, release := s.acquire()
+	defer release()
+
+	res := cfg.Scheduler.Explain(ctx, c.Task, c.Nodes)
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	_ = s.writeLog(ctx, cfg, c, res.Result)
+
+	return explainHelper(&res), nil
+}
+
+func (s *server) sendHelper(_ context.Context, task *pb.Task, nodes []*pb.Node) (*common.Task, []*common.Node, error) {
 	var buf []*common.Node
 
-	taskHelper := func(t *pb.Task) common.Resource {
-		return common.Resource{
-			MilliCPU: t.GetRequestedResource().MilliCPU,
-			Memory:   t.GetRequestedResource().Memory,
-			Storage:  t.GetRequestedResource().Storage,
-		}
+	for _, item := range nodes {
+		buf = append(buf, nodeHelper(item))
+	}
+
+	return taskHelper(task), buf, nil
+}
+
+func (s *server) sendBatchHelper(_ context.Context, tasks []*pb.Task, nodes []*pb.Node) ([]*common.Task, []*common.Node, error) {
+	var tb []*common.Task
+	var nb []*common.Node
+
+	for _, item := range tasks {
+		tb = append(tb, taskHelper(item))
+	}
+
+	for _, item := range nodes {
+		nb = append(nb, nodeHelper(item))
 	}
 
-	nodeHelper := func(n *pb.Node) common.Node {
-		return common.Node{
-			AllocatableResource: common.Resource{
-				MilliCPU: n.GetAllocatableResource().MilliCPU,
-				Memory:   n.GetAllocatableResource().Memory,
-				Storage:  n.GetAllocatableResource().Storage,
-			},
-			Host:  n.GetHost(),
-			Label: n.GetLabel(),
-			Name:  n.GetName(),
-			RequestedResource: common.Resource{
-				MilliCPU: n.GetRequestedResource().MilliCPU,
-				Memory:   n.GetRequestedResource().Memory,
-				Storage:  n.GetRequestedResource().Storage,
-			},
-			Unschedulable: n.GetUnschedulable(),
+	return tb, nb, nil
+}
+
+func explainHelper(e *scheduler.Explanation) *pb.ExplainReply {
+	buf := make([]*pb.NodeExplain, len(e.Nodes))
+
+	for i := range e.Nodes {
+		n := &e.Nodes[i]
+		buf[i] = &pb.NodeExplain{
+			Name:     n.Name,
+			Feasible: n.Feasible,
+			Total:    n.Total,
+		}
+		for _, item := range n.Filters {
+			buf[i].Filters = append(buf[i].Filters, &pb.FilterVerdict{Plugin: item.Plugin, Error: item.Error})
+		}
+		for _, item := range n.Scores {
+			buf[i].Scores = append(buf[i].Scores, &pb.PluginScore{
+				Plugin:     item.Plugin,
+				Score:      item.Score,
+				Weight:     item.Weight,
+				Normalized: item.Normalized,
+			})
 		}
 	}
 
-	s.task = &common.Task{
-		Name:                   task.GetName(),
-		NodeName:               task.GetNodeName(),
-		NodeSelectors:          task.GetNodeSelectors(),
-		RequestedResource:      taskHelper(task),
-		ToleratesUnschedulable: task.GetToleratesUnschedulable(),
+	return &pb.ExplainReply{
+		Name:    e.Name,
+		Error:   e.Error,
+		Nodes:   buf,
+		Ties:    e.Ties,
+		Code:    errorCodes[e.Code],
+		Details: e.Details,
 	}
+}
 
-	for _, item := range nodes {
-		node := nodeHelper(item)
-		buf = append(buf, &node)
+func replyHelper(r *scheduler.Result) *pb.ServerReply {
+	var buf []*pb.Candidate
+
+	for _, item := range r.Candidates {
+		buf = append(buf, &pb.Candidate{Name: item.Name, Score: item.Score})
+	}
+
+	return &pb.ServerReply{
+		Name:       r.Name,
+		Error:      r.Error,
+		Code:       errorCodes[r.Code],
+		Details:    r.Details,
+		Candidates: buf,
+	}
+}
+
+// candidateHelper keeps the first n candidates, and none for n <= 0.
+func candidateHelper(c []scheduler.Candidate, n int64) []scheduler.Candidate {
+	if n <= 0 {
+		return nil
+	}
+
+	if n < int64(len(c)) {
+		return c[:n]
+	}
+
+	return c
+}
+
+// statusHelper returns the gRPC status error of code, with the reply and any
+// further details attached. It returns nil for ERROR_CODE_OK.
+func statusHelper(code pb.ErrorCode, msg string, reply protoadapt.MessageV1, details ...protoadapt.MessageV1) error {
+	if code == pb.ErrorCode_ERROR_CODE_OK {
+		return nil
+	}
+
+	st := status.New(statusCodes[code], msg)
+	if buf, err := st.WithDetails(append([]protoadapt.MessageV1{reply}, details...)...); err == nil {
+		st = buf
 	}
 
-	return buf, nil
+	return st.Err()
+}
+
+// drainHelper returns how long the requests in flight on cfg are waited for.
+func drainHelper(cfg *Config) time.Duration {
+	if t := cfg.Config.Spec.Server.Shutdown.Timeout; t > 0 {
+		return t
+	}
+
+	return drainTimeout
+}
+
+func kindDetails(kind string) string {
+	return fmt.Sprintf("kind: must be %q, got %q", Kind, kind)
+}
+
+func taskHelper(t *pb.Task) *common.Task {
+	return &common.Task{
+		Name:          t.GetName(),
+		NodeName:      t.GetNodeName(),
+		NodeSelectors: t.GetNodeSelectors(),
+		RequestedResource: common.Resource{
+			MilliCPU: t.GetRequestedResource().GetMilliCPU(),
+			Memory:   t.GetRequestedResource().GetMemory(),
+			Storage:  t.GetRequestedResource().GetStorage(),
+		},
+		ToleratesUnschedulable: t.GetToleratesUnschedulable(),
+	}
+}
+
+func nodeHelper(n *pb.Node) *common.Node {
+	return &common.Node{
+		AllocatableResource: common.Resource{
+			MilliCPU: n.GetAllocatableResource().GetMilliCPU(),
+			Memory:   n.GetAllocatableResource().GetMemory(),
+			Storage:  n.GetAllocatableResource().GetStorage(),
+		},
+		Host:  n.GetHost(),
+		Label: n.GetLabel(),
+		Name:  n.GetName(),
+		RequestedResource: common.Resource{
+			MilliCPU: n.GetRequestedResource().GetMilliCPU(),
+			Memory:   n.GetRequestedResource().GetMemory(),
+			Storage:  n.GetRequestedResource().GetStorage(),
+		},
+		Unschedulable: n.GetUnschedulable(),
+	}
+}
+
+// requestID returns the ID from the x-request-id metadata of the caller, or a
+// random one.
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if buf := md.Get(RequestIDKey); len(buf) != 0 && buf[0] != "" {
+			return buf[0]
+		}
+	}
+
+	buf := make([]byte, requestIDLen)
+	_, _ = rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
+
+func (s *server) writeLog(_ context.Context, cfg *Config, c *common.Cycle, result scheduler.Result) error {
+	cfg.Logger.Info("server", zap.String("id", c.ID), zap.Any("task", c.Task), zap.Any("nodes", c.Nodes), zap.Any("result", result))
+	return nil
 }
 
-func (s *server) writeLog(_ context.Context, task *common.Task, nodes []*common.Node, result scheduler.Result) error {
-	s.cfg.Logger.Info("server", zap.Any("task", task), zap.Any("nodes", nodes), zap.Any("result", result))
+func (s *server) writeBatchLog(_ context.Context, cfg *Config, c *common.Cycle, tasks []*common.Task, results []scheduler.Result) error {
+	cfg.Logger.Info("server", zap.String("id", c.ID), zap.Any("tasks", tasks), zap.Any("nodes", c.Nodes), zap.Any("results", results))
 	return nil
 }
@@ -3,12 +3,29 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
+	"path/filepath"
+	"runtime"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/pipego/scheduler/common"
+	"github.com/pipego/scheduler/config"
+	"github.com/pipego/scheduler/parallelizer"
+	"github.com/pipego/scheduler/plugin"
+	"github.com/pipego/scheduler/scheduler"
 	mock "github.com/pipego/scheduler/server/mock"
 	pb "github.com/pipego/scheduler/server/proto"
 
@@ -23,6 +40,101 @@ type rpcTest struct {
 	grpctest.Tester
 }
 
+type testLogger struct{}
+
+func (l *testLogger) Init(context.Context) error   { return nil }
+func (l *testLogger) Deinit(context.Context) error { return nil }
+func (l *testLogger) Debug(string, ...zap.Field)   {}
+func (l *testLogger) Info(string, ...zap.Field)    {}
+func (l *testLogger) Warn(string, ...zap.Field)    {}
+func (l *testLogger) Error(string, ...zap.Field)   {}
+
+// testScheduler places each task on the node named after it, or on the first
+// node if the task is unnamed.
+type testScheduler struct {
+	health error
+	delay  time.Duration
+	closed bool
+}
+
+func (s *testScheduler) Init(context.Context) error   { return nil }
+func (s *testScheduler) Deinit(context.Context) error { s.closed = true; return nil }
+func (s *testScheduler) Health(context.Context) error { return s.health }
+
+func (s *testScheduler) Run(ctx context.Context, task *common.Task, nodes []*common.Node) scheduler.Result {
+	runtime.Gosched()
+	time.Sleep(s.delay)
+
+	if task.Name == "" && len(nodes) != 0 {
+		return scheduler.Result{Name: nodes[0].Name}
+	}
+
+	for _, item := range nodes {
+		if item.Name == task.Name {
+			return scheduler.Result{Name: item.Name}
+		}
+	}
+
+	return scheduler.Result{Error: "failed to filter", Code: scheduler.CodeUnschedulable}
+}
+
+func (s *testScheduler) RunBatch(ctx context.Context, tasks []*common.Task, nodes []*common.Node) []scheduler.Result {
+	var buf []scheduler.Result
+
+	for _, item := range tasks {
+		buf = append(buf, s.Run(ctx, item, nodes))
+	}
+
+	return buf
+}
+
+func (s *testScheduler) Explain(ctx context.Context, task *common.Task, nodes []*common.Node) scheduler.Explanation {
+	return scheduler.Explanation{Result: s.Run(ctx, task, nodes)}
+}
+
+// testFitPlugin rejects the nodes without enough CPU left for the task in the
+// NodeResourcesFit filter, and scores every node equally.
+type testFitPlugin struct {
+	plugin.Plugin
+}
+
+func (p *testFitPlugin) RunPreFilter(context.Context, string, *common.Task) (plugin.PreFilterResult, error) {
+	return plugin.PreFilterResult{}, nil
+}
+
+func (p *testFitPlugin) RunFilter(_ context.Context, _ string, task *common.Task, node *common.Node) (plugin.FilterResult, error) {
+	if node.RequestedResource.MilliCPU+task.RequestedResource.MilliCPU > node.AllocatableResource.MilliCPU {
+		return plugin.FilterResult{Error: "Insufficient cpu"}, nil
+	}
+
+	return plugin.FilterResult{}, nil
+}
+
+func (p *testFitPlugin) RunPreScore(context.Context, string, *common.Task, []*common.Node) (plugin.PreScoreResult, error) {
+	return plugin.PreScoreResult{}, nil
+}
+
+func (p *testFitPlugin) RunScore(context.Context, string, *common.Task, *common.Node) (plugin.ScoreResult, error) {
+	return plugin.ScoreResult{Score: 1}, nil
+}
+
+func (p *testFitPlugin) RunNormalizeScore(context.Context, string, *common.Task, []*common.Node, []int64) (plugin.NormalizeScoreResult, error) {
+	return plugin.NormalizeScoreResult{}, nil
+}
+
+func testServer() *server {
+	return &server{
+		cfg: &Config{
+			Addresses: []string{"127.0.0.1:0"},
+			Logger:    &testLogger{},
+			Scheduler: &testScheduler{},
+		},
+		wg:    &sync.WaitGroup{},
+		jobs:  newJobStore(),
+		dedup: newIdempotency(),
+	}
+}
+
 func TestServer(t *testing.T) {
 	grpctest.RunSubTests(t, rpcTest{})
 }
@@ -67,6 +179,499 @@ func (rpcTest) TestSendServer(t *testing.T) {
 	helper(t, client)
 }
 
+func (rpcTest) TestSendServerBatch(t *testing.T) {
+	helper := func(t *testing.T, client pb.ServerProtoClient) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		r, err := client.SendServerBatch(ctx, &pb.ServerBatchRequest{ApiVersion: "v1"})
+		if err != nil || r.GetError() != "" || len(r.GetReplies()) != 2 {
+			t.Errorf("mocking failed")
+		}
+
+		t.Log("reply: ", r.GetReplies())
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := &pb.ServerBatchRequest{ApiVersion: "v1"}
+
+	client := mock.NewMockServerProtoClient(ctrl)
+	client.EXPECT().SendServerBatch(
+		gomock.Any(),
+		&rpcMsg{msg: req},
+	).Return(&pb.ServerBatchReply{Replies: []*pb.ServerReply{{Name: "node1"}, {Name: "node2"}}}, nil)
+
+	helper(t, client)
+}
+
+func (rpcTest) TestSendServerBatchFit(t *testing.T) {
+	ctx := context.Background()
+
+	c := config.Config{
+		Spec: config.Spec{
+			Filter: config.Plugin{Enabled: []config.Enabled{{Name: "NodeResourcesFit"}}},
+			Score:  config.Plugin{Enabled: []config.Enabled{{Name: "Score", Weight: 1}}},
+		},
+	}
+
+	s := testServer()
+	s.cfg.Config = c
+	s.cfg.Scheduler = scheduler.New(ctx, &scheduler.Config{
+		Config:       c,
+		Parallelizer: parallelizer.New(ctx, &parallelizer.Config{Config: c}),
+		Plugin:       &testFitPlugin{},
+	})
+
+	// The tasks fit on a node one at a time, but not together
+	helper := func(names ...string) *pb.ServerBatchReply {
+		var nodes []*pb.Node
+		for _, item := range names {
+			nodes = append(nodes, &pb.Node{Name: item, AllocatableResource: &pb.AllocatableResource{MilliCPU: 1000}})
+		}
+		r, err := s.SendServerBatch(ctx, &pb.ServerBatchRequest{
+			ApiVersion: ApiVersion,
+			Kind:       Kind,
+			Spec: &pb.BatchSpec{
+				Tasks: []*pb.Task{
+					{Name: "task1", RequestedResource: &pb.RequestedResource{MilliCPU: 600}},
+					{Name: "task2", RequestedResource: &pb.RequestedResource{MilliCPU: 600}},
+				},
+				Nodes: nodes,
+			},
+		})
+		if err != nil || len(r.GetReplies()) != 2 {
+			t.Fatalf("invalid reply: %v", err)
+		}
+		return r
+	}
+
+	r := helper("node1", "node2")
+	if r.GetReplies()[0].GetError() != "" || r.GetReplies()[1].GetError() != "" {
+		t.Errorf("invalid error: %v", r.GetReplies())
+	}
+
+	if r.GetReplies()[0].GetName() == r.GetReplies()[1].GetName() {
+		t.Errorf("node over-committed: %v", r.GetReplies())
+	}
+
+	r = helper("node1")
+	if r.GetReplies()[0].GetName() != "node1" ||
+		r.GetReplies()[1].GetCode() != pb.ErrorCode_ERROR_CODE_UNSCHEDULABLE {
+		t.Errorf("node over-committed: %v", r.GetReplies())
+	}
+}
+
+func (rpcTest) TestExplain(t *testing.T) {
+	helper := func(t *testing.T, client pb.ServerProtoClient) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		r, err := client.Explain(ctx, &pb.ServerRequest{ApiVersion: "v1"})
+		if err != nil || r.GetError() != "" || r.GetName() != "node" || len(r.GetNodes()) != 1 {
+			t.Errorf("mocking failed")
+		}
+
+		t.Log("reply: ", r.GetNodes())
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := &pb.ServerRequest{ApiVersion: "v1"}
+
+	client := mock.NewMockServerProtoClient(ctrl)
+	client.EXPECT().Explain(
+		gomock.Any(),
+		&rpcMsg{msg: req},
+	).Return(&pb.ExplainReply{Name: "node", Nodes: []*pb.NodeExplain{{Name: "node", Feasible: true}}}, nil)
+
+	helper(t, client)
+}
+
+func (rpcTest) TestExplainHelper(t *testing.T) {
+	e := scheduler.Explanation{
+		Result: scheduler.Result{Name: "node1"},
+		Nodes: []scheduler.NodeExplanation{
+			{
+				Name:     "node1",
+				Feasible: true,
+				Filters:  []scheduler.FilterVerdict{{Plugin: "NodeName"}},
+				Scores:   []scheduler.PluginScore{{Plugin: "NodeResourcesFit", Score: 200, Normalized: 10, Weight: 2}},
+				Total:    20,
+			},
+			{
+				Name:    "node2",
+				Filters: []scheduler.FilterVerdict{{Plugin: "NodeName", Error: "invalid name"}},
+			},
+		},
+		Ties: []string{"node1"},
+	}
+
+	r := explainHelper(&e)
+	if r.GetName() != "node1" || len(r.GetNodes()) != 2 || len(r.GetTies()) != 1 {
+		t.Fatalf("invalid helper")
+	}
+
+	if r.GetNodes()[0].GetTotal() != 20 || r.GetNodes()[0].GetScores()[0].GetWeight() != 2 ||
+		r.GetNodes()[0].GetScores()[0].GetScore() != 200 || r.GetNodes()[0].GetScores()[0].GetNormalized() != 10 {
+		t.Errorf("invalid score")
+	}
+
+	if r.GetNodes()[1].GetFeasible() || r.GetNodes()[1].GetFilters()[0].GetError() != "invalid name" {
+		t.Errorf("invalid filter")
+	}
+}
+
+func (rpcTest) TestReplyHelper(t *testing.T) {
+	r := replyHelper(&scheduler.Result{Error: "failed to filter", Code: scheduler.CodeUnschedulable, Details: "details"})
+	if r.GetError() != "failed to filter" || r.GetCode() != pb.ErrorCode_ERROR_CODE_UNSCHEDULABLE || r.GetDetails() != "details" {
+		t.Errorf("invalid helper")
+	}
+}
+
+func (rpcTest) TestCandidateHelper(t *testing.T) {
+	c := []scheduler.Candidate{{Name: "node1", Score: 2}, {Name: "node2", Score: 1}}
+
+	if buf := candidateHelper(c, 0); len(buf) != 0 {
+		t.Errorf("invalid helper")
+	}
+
+	if buf := candidateHelper(c, 1); len(buf) != 1 || buf[0].Name != "node1" {
+		t.Errorf("invalid helper")
+	}
+
+	if buf := candidateHelper(c, 3); len(buf) != 2 {
+		t.Errorf("invalid helper")
+	}
+
+	r := replyHelper(&scheduler.Result{Name: "node1", Candidates: c})
+	if len(r.GetCandidates()) != 2 || r.GetCandidates()[1].GetScore() != 1 {
+		t.Errorf("invalid reply")
+	}
+}
+
+func (rpcTest) TestStatusHelper(t *testing.T) {
+	reply := &pb.ServerReply{Name: "node"}
+	if err := statusHelper(reply.GetCode(), reply.GetError(), reply); err != nil {
+		t.Errorf("invalid status")
+	}
+
+	reply = &pb.ServerReply{Error: "failed to filter", Code: pb.ErrorCode_ERROR_CODE_UNSCHEDULABLE}
+
+	st, _ := status.FromError(statusHelper(reply.GetCode(), reply.GetError(), reply))
+	if st.Code() != codes.FailedPrecondition || st.Message() != "failed to filter" || len(st.Details()) != 1 {
+		t.Fatalf("invalid status")
+	}
+
+	if r, ok := st.Details()[0].(*pb.ServerReply); !ok || r.GetCode() != pb.ErrorCode_ERROR_CODE_UNSCHEDULABLE {
+		t.Errorf("invalid details")
+	}
+
+	reply = &pb.ServerReply{Error: "invalid kind", Code: pb.ErrorCode_ERROR_CODE_INVALID_KIND}
+
+	st, _ = status.FromError(statusHelper(reply.GetCode(), reply.GetError(), reply))
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("invalid status")
+	}
+}
+
+func (rpcTest) TestSendServerConcurrent(t *testing.T) {
+	var nodes []*pb.Node
+
+	count := 100
+	s := testServer()
+
+	for i := 0; i < count; i++ {
+		nodes = append(nodes, &pb.Node{Name: fmt.Sprintf("node%d", i)})
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(count)
+
+	for i := 0; i < count; i++ {
+		go func(i int) {
+			defer wg.Done()
+			req := &pb.ServerRequest{
+				ApiVersion: ApiVersion,
+				Kind:       Kind,
+				Spec: &pb.Spec{
+					Task:  &pb.Task{Name: fmt.Sprintf("node%d", i)},
+					Nodes: nodes,
+				},
+			}
+			r, err := s.SendServer(context.Background(), req)
+			if err != nil || r.GetName() != req.GetSpec().GetTask().GetName() {
+				t.Errorf("invalid reply: %v, %v", r.GetName(), err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func (rpcTest) TestHealth(t *testing.T) {
+	ctx := context.Background()
+	req := &healthpb.HealthCheckRequest{Service: pb.ServerProto_ServiceDesc.ServiceName}
+
+	s := testServer()
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("failed to init")
+	}
+
+	if r, err := s.health.Check(ctx, req); err != nil || r.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("invalid status")
+	}
+
+	s.cfg.Scheduler.(*testScheduler).health = errors.New("plugin exited")
+	s.setServing(s.cfg.Scheduler.Health(ctx) == nil)
+
+	if r, err := s.health.Check(ctx, req); err != nil || r.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("invalid status")
+	}
+
+	s.cfg.Scheduler.(*testScheduler).health = nil
+	s.setServing(s.cfg.Scheduler.Health(ctx) == nil)
+
+	_ = s.Deinit(ctx)
+
+	if r, err := s.health.Check(ctx, req); err != nil || r.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("invalid status")
+	}
+}
+
 func (rpcTest) TestSendHelper(t *testing.T) {
-	// BYPASS
+	s := server{}
+
+	task, nodes, err := s.sendHelper(context.Background(), &pb.Task{Name: "task1"}, []*pb.Node{{Name: "node1"}})
+	if err != nil || task.Name != "task1" || len(nodes) != 1 || nodes[0].Name != "node1" {
+		t.Errorf("invalid helper")
+	}
+}
+
+func (rpcTest) TestRequestID(t *testing.T) {
+	if id := requestID(context.Background()); len(id) != requestIDLen*2 {
+		t.Errorf("invalid id")
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "id1"))
+	if id := requestID(ctx); id != "id1" {
+		t.Errorf("invalid id")
+	}
+}
+
+func (rpcTest) TestSendBatchHelper(t *testing.T) {
+	s := server{}
+
+	tasks := []*pb.Task{
+		{Name: "task1", RequestedResource: &pb.RequestedResource{MilliCPU: 1}},
+		{Name: "task2"},
+	}
+
+	nodes := []*pb.Node{
+		{Name: "node1", AllocatableResource: &pb.AllocatableResource{MilliCPU: 2}},
+	}
+
+	tb, nb, err := s.sendBatchHelper(context.Background(), tasks, nodes)
+	if err != nil || len(tb) != 2 || len(nb) != 1 {
+		t.Fatalf("invalid helper")
+	}
+
+	if tb[0].RequestedResource.MilliCPU != 1 || tb[1].RequestedResource.MilliCPU != 0 {
+		t.Errorf("invalid task")
+	}
+
+	if nb[0].AllocatableResource.MilliCPU != 2 || nb[0].RequestedResource.MilliCPU != 0 {
+		t.Errorf("invalid node")
+	}
+}
+
+func (rpcTest) TestDeinit(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	s.cfg.Config.Spec.Server.Shutdown.Timeout = 5 * time.Second
+	s.cfg.Scheduler.(*testScheduler).delay = 500 * time.Millisecond
+
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("failed to init")
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen")
+	}
+
+	go func() {
+		_ = s.srv.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(ctx, lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial")
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	req := &pb.ServerRequest{
+		ApiVersion: ApiVersion,
+		Kind:       Kind,
+		Spec: &pb.Spec{
+			Task:  &pb.Task{Name: "node1"},
+			Nodes: []*pb.Node{{Name: "node1"}},
+		},
+	}
+
+	done := make(chan error)
+
+	go func() {
+		_, err := pb.NewServerProtoClient(conn).SendServer(ctx, req)
+		done <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	_ = s.Deinit(ctx)
+
+	if err := <-done; err != nil {
+		t.Errorf("in-flight request failed: %v", err)
+	}
+
+	if !s.cfg.Scheduler.(*testScheduler).closed {
+		t.Errorf("scheduler not deinit")
+	}
+}
+
+func (rpcTest) TestReload(t *testing.T) {
+	ctx := context.Background()
+
+	s := testServer()
+	s.cfg.Scheduler.(*testScheduler).delay = 500 * time.Millisecond
+
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("failed to init")
+	}
+
+	req := &pb.ServerRequest{
+		ApiVersion: ApiVersion,
+		Kind:       Kind,
+		Spec: &pb.Spec{
+			Task:  &pb.Task{Name: "node1"},
+			Nodes: []*pb.Node{{Name: "node1"}},
+		},
+	}
+
+	old := s.cfg.Scheduler.(*testScheduler)
+	done := make(chan error)
+
+	go func() {
+		_, err := s.SendServer(ctx, req)
+		done <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	err := s.Reload(ctx, &Config{Logger: &testLogger{}, Scheduler: &testScheduler{health: errors.New("plugin exited")}})
+	if err == nil || s.config().Scheduler != old {
+		t.Errorf("invalid reload")
+	}
+
+	next := &testScheduler{}
+
+	if err := s.Reload(ctx, &Config{Logger: &testLogger{}, Scheduler: next}); err != nil {
+		t.Errorf("failed to reload: %v", err)
+	}
+
+	// The old config is drained in the background
+	select {
+	case <-done:
+		t.Errorf("reload waited for the old config")
+	default:
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("in-flight request failed: %v", err)
+	}
+
+	if next.closed || s.config().Scheduler != next {
+		t.Errorf("invalid scheduler")
+	}
+
+	if r, err := s.SendServer(ctx, req); err != nil || r.GetName() != "node1" {
+		t.Errorf("invalid reply: %v, %v", r.GetName(), err)
+	}
+
+	_ = s.Deinit(ctx)
+
+	if !old.closed {
+		t.Errorf("old scheduler not deinit")
+	}
+}
+
+func (rpcTest) TestSendServerDeadline(t *testing.T) {
+	s := testServer()
+	s.cfg.Scheduler.(*testScheduler).delay = 200 * time.Millisecond
+
+	req := &pb.ServerRequest{
+		ApiVersion: ApiVersion,
+		Kind:       Kind,
+		Spec: &pb.Spec{
+			Task:  &pb.Task{Name: "node1"},
+			Nodes: []*pb.Node{{Name: "node1"}},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := s.SendServer(ctx, req); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("invalid status: %v", err)
+	}
+}
+
+func (rpcTest) TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "scheduler.sock")
+
+	s := testServer()
+	s.cfg.Addresses = []string{"127.0.0.1:0", "unix://" + path}
+
+	if err := s.Init(ctx); err != nil {
+		t.Fatalf("failed to init")
+	}
+
+	done := make(chan error)
+
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	for _, item := range []string{s.lis[0].Addr().String(), "unix://" + path} {
+		conn, err := grpc.DialContext(ctx, item, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("failed to dial")
+		}
+		req := &healthpb.HealthCheckRequest{Service: pb.ServerProto_ServiceDesc.ServiceName}
+		if r, err := healthpb.NewHealthClient(conn).Check(ctx, req); err != nil || r.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("invalid status: %s", item)
+		}
+		_ = conn.Close()
+	}
+
+	_ = s.Deinit(ctx)
+
+	if err := <-done; err != nil {
+		t.Errorf("failed to run: %v", err)
+	}
+
+	s = testServer()
+	s.cfg.Addresses = []string{"127.0.0.1:0", "invalid://"}
+
+	if err := s.Init(ctx); err == nil {
+		t.Errorf("invalid address")
+	}
 }
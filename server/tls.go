@@ -0,0 +1,169 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/pipego/scheduler/config"
+	"github.com/pipego/scheduler/logger"
+)
+
+// tlsLoader serves the certificates of config.Tls, and reloads them when the
+// files change.
+type tlsLoader struct {
+	cfg    config.Tls
+	logger logger.Logger
+	mutex  sync.RWMutex
+	cert   *tls.Certificate
+	pool   *x509.CertPool
+	paths  map[string]string
+}
+
+func newTlsLoader(cfg config.Tls, log logger.Logger) (*tlsLoader, error) {
+	t := &tlsLoader{
+		cfg:    cfg,
+		logger: log,
+		paths:  make(map[string]string),
+	}
+
+	for _, item := range []string{cfg.CaFile, cfg.CertFile, cfg.KeyFile} {
+		if item == "" {
+			continue
+		}
+		p, err := filepath.Abs(item)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get path")
+		}
+		t.paths[p], _ = filepath.EvalSymlinks(p)
+	}
+
+	if err := t.load(); err != nil {
+		return nil, errors.Wrap(err, "failed to load")
+	}
+
+	return t, nil
+}
+
+func (t *tlsLoader) load() error {
+	var pool *x509.CertPool
+
+	cert, err := tls.LoadX509KeyPair(t.cfg.CertFile, t.cfg.KeyFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to load key pair")
+	}
+
+	if t.cfg.CaFile != "" {
+		buf, err := os.ReadFile(t.cfg.CaFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to read ca")
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(buf) {
+			return errors.New("invalid ca")
+		}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.cert = &cert
+	t.pool = pool
+
+	return nil
+}
+
+// config returns the TLS config negotiating protos, which picks up the latest
+// certificates on every handshake.
+func (t *tlsLoader) config(protos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			t.mutex.RLock()
+			defer t.mutex.RUnlock()
+			c := &tls.Config{
+				Certificates: []tls.Certificate{*t.cert},
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   protos,
+			}
+			if t.pool != nil {
+				c.ClientAuth = tls.RequireAndVerifyClientCert
+				c.ClientCAs = t.pool
+			}
+			return c, nil
+		},
+	}
+}
+
+// watch reloads the certificates on file changes until done is closed. The
+// directories are watched instead of the files, so that files replaced by
+// rename or symlink swap are picked up as well.
+func (t *tlsLoader) watch(done <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "failed to create watcher")
+	}
+
+	dirs := make(map[string]bool)
+
+	for key := range t.paths {
+		dirs[filepath.Dir(key)] = true
+	}
+
+	for key := range dirs {
+		if err := watcher.Add(key); err != nil {
+			_ = watcher.Close()
+			return errors.Wrap(err, "failed to watch")
+		}
+	}
+
+	go func() {
+		defer func() {
+			_ = watcher.Close()
+		}()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !t.changed(event) {
+					continue
+				}
+				if err := t.load(); err != nil {
+					t.logger.Error("server", zap.String("tls", "failed to reload"), zap.Error(err))
+				} else {
+					t.logger.Info("server", zap.String("tls", "reloaded"))
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (t *tlsLoader) changed(event fsnotify.Event) bool {
+	var changed bool
+
+	for key, val := range t.paths {
+		real, _ := filepath.EvalSymlinks(key)
+		if filepath.Clean(event.Name) == key || real != val {
+			changed = true
+		}
+		t.paths[key] = real
+	}
+
+	return changed
+}
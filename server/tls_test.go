@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+
+	"github.com/pipego/scheduler/config"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Equal(t, nil, err)
+
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	assert.Equal(t, nil, err)
+
+	cert, _ := x509.ParseCertificate(der)
+
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) write(t *testing.T, certFile, keyFile string) {
+	buf, _ := x509.MarshalECPrivateKey(c.key)
+
+	err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600)
+	assert.Equal(t, nil, err)
+
+	if keyFile != "" {
+		err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: buf}), 0600)
+		assert.Equal(t, nil, err)
+	}
+}
+
+func (c *testCert) tls() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+func TestTlsLoader(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := config.Tls{
+		CaFile:   filepath.Join(dir, "ca.crt"),
+		CertFile: filepath.Join(dir, "server.crt"),
+		KeyFile:  filepath.Join(dir, "server.key"),
+	}
+
+	_, err := newTlsLoader(cfg, &testLogger{})
+	assert.NotEqual(t, nil, err)
+
+	ca := newTestCert(t, "ca", nil)
+	ca.write(t, cfg.CaFile, "")
+
+	srv := newTestCert(t, "server", ca)
+	srv.write(t, cfg.CertFile, cfg.KeyFile)
+
+	loader, err := newTlsLoader(cfg, &testLogger{})
+	assert.Equal(t, nil, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	err = loader.watch(done)
+	assert.Equal(t, nil, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Equal(t, nil, err)
+
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(loader.config("h2"))))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+
+	go func() {
+		_ = s.Serve(lis)
+	}()
+
+	defer s.Stop()
+
+	helper := func(certs []tls.Certificate) (*x509.Certificate, error) {
+		pool := x509.NewCertPool()
+		pool.AddCert(ca.cert)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, lis.Addr().String(),
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+				Certificates: certs,
+				MinVersion:   tls.VersionTLS12,
+				RootCAs:      pool,
+				ServerName:   "localhost",
+			})))
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		p := peer.Peer{}
+		if _, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Peer(&p)); err != nil {
+			return nil, err
+		}
+		return p.AuthInfo.(credentials.TLSInfo).State.PeerCertificates[0], nil
+	}
+
+	// Client certificate is required
+	_, err = helper(nil)
+	assert.NotEqual(t, nil, err)
+
+	cli := newTestCert(t, "client", ca)
+
+	buf, err := helper([]tls.Certificate{cli.tls()})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, srv.cert.SerialNumber, buf.SerialNumber)
+
+	// Certificates are reloaded on file changes
+	srv = newTestCert(t, "server", ca)
+	srv.write(t, cfg.CertFile, cfg.KeyFile)
+
+	assert.Eventually(t, func() bool {
+		buf, err = helper([]tls.Certificate{cli.tls()})
+		return err == nil && buf.SerialNumber.Cmp(srv.cert.SerialNumber) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestInitTls(t *testing.T) {
+	s := testServer()
+	s.cfg.Config.Spec.Server.Tls = config.Tls{CaFile: filepath.Join(t.TempDir(), "ca.crt")}
+
+	err := s.Init(context.Background())
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, "invalid tls", err.Error())
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+const (
+	ApiVersion = "v1"
+)
+
+// violation is a field-level validation error, e.g.
+// `spec.nodes[3].name: duplicate "node1"`.
+type violation struct {
+	field       string
+	description string
+}
+
+type resourceGetter interface {
+	GetMilliCPU() int64
+	GetMemory() int64
+	GetStorage() int64
+}
+
+func (v violation) String() string {
+	return v.field + ": " + v.description
+}
+
+func validateRequest(in *pb.ServerRequest) []violation {
+	var buf []violation
+
+	buf = append(buf, validateHeader(in.GetApiVersion(), in.GetTopN())...)
+
+	if in.GetSpec() == nil {
+		return append(buf, violation{"spec", "must be set"})
+	}
+
+	if in.GetSpec().GetTask() == nil {
+		buf = append(buf, violation{"spec.task", "must be set"})
+	} else {
+		buf = append(buf, validateTask("spec.task", in.GetSpec().GetTask())...)
+	}
+
+	return append(buf, validateNodes("spec.nodes", in.GetSpec().GetNodes())...)
+}
+
+func validateBatchRequest(in *pb.ServerBatchRequest) []violation {
+	var buf []violation
+
+	buf = append(buf, validateHeader(in.GetApiVersion(), in.GetTopN())...)
+
+	if in.GetSpec() == nil {
+		return append(buf, violation{"spec", "must be set"})
+	}
+
+	if len(in.GetSpec().GetTasks()) == 0 {
+		buf = append(buf, violation{"spec.tasks", "must not be empty"})
+	}
+
+	for i, item := range in.GetSpec().GetTasks() {
+		field := fmt.Sprintf("spec.tasks[%d]", i)
+		if item == nil {
+			buf = append(buf, violation{field, "must be set"})
+			continue
+		}
+		buf = append(buf, validateTask(field, item)...)
+	}
+
+	return append(buf, validateNodes("spec.nodes", in.GetSpec().GetNodes())...)
+}
+
+func validateHeader(version string, topN int64) []violation {
+	var buf []violation
+
+	if version != ApiVersion {
+		buf = append(buf, violation{"apiVersion", fmt.Sprintf("must be %q, got %q", ApiVersion, version)})
+	}
+
+	if topN < 0 {
+		buf = append(buf, violation{"topN", "must be >= 0"})
+	}
+
+	return buf
+}
+
+func validateTask(field string, task *pb.Task) []violation {
+	return validateResource(field+".requestedResource", task.GetRequestedResource())
+}
+
+func validateNodes(field string, nodes []*pb.Node) []violation {
+	var buf []violation
+
+	names := make(map[string]bool)
+
+	for i, item := range nodes {
+		f := fmt.Sprintf("%s[%d]", field, i)
+		if item == nil {
+			buf = append(buf, violation{f, "must be set"})
+			continue
+		}
+		if item.GetName() == "" {
+			buf = append(buf, violation{f + ".name", "must be set"})
+		} else if names[item.GetName()] {
+			buf = append(buf, violation{f + ".name", fmt.Sprintf("duplicate %q", item.GetName())})
+		}
+		names[item.GetName()] = true
+		buf = append(buf, validateResource(f+".allocatableResource", item.GetAllocatableResource())...)
+		buf = append(buf, validateResource(f+".requestedResource", item.GetRequestedResource())...)
+	}
+
+	return buf
+}
+
+func validateResource(field string, res resourceGetter) []violation {
+	var buf []violation
+
+	if res.GetMilliCPU() < 0 {
+		buf = append(buf, violation{field + ".milliCPU", "must be >= 0"})
+	}
+
+	if res.GetMemory() < 0 {
+		buf = append(buf, violation{field + ".memory", "must be >= 0"})
+	}
+
+	if res.GetStorage() < 0 {
+		buf = append(buf, violation{field + ".storage", "must be >= 0"})
+	}
+
+	return buf
+}
+
+func violationHelper(v []violation) string {
+	buf := make([]string, len(v))
+
+	for i := range v {
+		buf[i] = v[i].String()
+	}
+
+	return strings.Join(buf, "; ")
+}
+
+func badRequestHelper(v []violation) *errdetails.BadRequest {
+	buf := &errdetails.BadRequest{}
+
+	for _, item := range v {
+		buf.FieldViolations = append(buf.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       item.field,
+			Description: item.description,
+		})
+	}
+
+	return buf
+}
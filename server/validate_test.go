@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/pipego/scheduler/server/proto"
+)
+
+func TestValidateRequest(t *testing.T) {
+	helper := func(in *pb.ServerRequest) []string {
+		var buf []string
+		for _, item := range validateRequest(in) {
+			buf = append(buf, item.String())
+		}
+		return buf
+	}
+
+	in := &pb.ServerRequest{
+		ApiVersion: ApiVersion,
+		Kind:       Kind,
+		Spec: &pb.Spec{
+			Task: &pb.Task{Name: "task1"},
+			Nodes: []*pb.Node{
+				{Name: "node1"},
+				{Name: "node2"},
+			},
+		},
+	}
+	assert.Equal(t, []string(nil), helper(in))
+
+	assert.Equal(t, []string{
+		`apiVersion: must be "v1", got ""`,
+		"spec: must be set",
+	}, helper(&pb.ServerRequest{}))
+
+	in = &pb.ServerRequest{
+		ApiVersion: ApiVersion,
+		TopN:       -1,
+		Spec: &pb.Spec{
+			Task: &pb.Task{
+				Name:              "task1",
+				RequestedResource: &pb.RequestedResource{MilliCPU: -1, Memory: -1},
+			},
+			Nodes: []*pb.Node{
+				{Name: "node1"},
+				{Name: ""},
+				{Name: "node1", AllocatableResource: &pb.AllocatableResource{Storage: -1}},
+			},
+		},
+	}
+	assert.Equal(t, []string{
+		"topN: must be >= 0",
+		"spec.task.requestedResource.milliCPU: must be >= 0",
+		"spec.task.requestedResource.memory: must be >= 0",
+		"spec.nodes[1].name: must be set",
+		`spec.nodes[2].name: duplicate "node1"`,
+		"spec.nodes[2].allocatableResource.storage: must be >= 0",
+	}, helper(in))
+
+	in = &pb.ServerRequest{ApiVersion: ApiVersion, Spec: &pb.Spec{}}
+	assert.Equal(t, []string{"spec.task: must be set"}, helper(in))
+}
+
+func TestValidateBatchRequest(t *testing.T) {
+	helper := func(in *pb.ServerBatchRequest) []string {
+		var buf []string
+		for _, item := range validateBatchRequest(in) {
+			buf = append(buf, item.String())
+		}
+		return buf
+	}
+
+	in := &pb.ServerBatchRequest{
+		ApiVersion: ApiVersion,
+		Spec: &pb.BatchSpec{
+			Tasks: []*pb.Task{{Name: "task1"}},
+			Nodes: []*pb.Node{{Name: "node1"}},
+		},
+	}
+	assert.Equal(t, []string(nil), helper(in))
+
+	in = &pb.ServerBatchRequest{ApiVersion: ApiVersion, Spec: &pb.BatchSpec{}}
+	assert.Equal(t, []string{"spec.tasks: must not be empty"}, helper(in))
+
+	in = &pb.ServerBatchRequest{
+		ApiVersion: ApiVersion,
+		Spec: &pb.BatchSpec{
+			Tasks: []*pb.Task{
+				{Name: "task1"},
+				{Name: "task2", RequestedResource: &pb.RequestedResource{Storage: -1}},
+			},
+		},
+	}
+	assert.Equal(t, []string{"spec.tasks[1].requestedResource.storage: must be >= 0"}, helper(in))
+}
+
+func TestSendServerInvalid(t *testing.T) {
+	s := testServer()
+
+	in := &pb.ServerRequest{
+		ApiVersion: "v2",
+		Kind:       Kind,
+		Spec: &pb.Spec{
+			Task:  &pb.Task{Name: "node1"},
+			Nodes: []*pb.Node{{Name: "node1"}, {Name: "node1"}},
+		},
+	}
+
+	_, err := s.SendServer(context.Background(), in)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	st := status.Convert(err)
+	assert.Equal(t, 2, len(st.Details()))
+
+	reply := st.Details()[0].(*pb.ServerReply)
+	assert.Equal(t, pb.ErrorCode_ERROR_CODE_INVALID_SPEC, reply.GetCode())
+	assert.Equal(t, `apiVersion: must be "v1", got "v2"; spec.nodes[1].name: duplicate "node1"`, reply.GetDetails())
+
+	br := st.Details()[1].(*errdetails.BadRequest)
+	assert.Equal(t, 2, len(br.GetFieldViolations()))
+	assert.Equal(t, "spec.nodes[1].name", br.GetFieldViolations()[1].GetField())
+
+	_, err = s.Explain(context.Background(), in)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.SendServerBatch(context.Background(), &pb.ServerBatchRequest{Kind: Kind})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}